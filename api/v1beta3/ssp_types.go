@@ -18,6 +18,8 @@ package v1beta3
 
 import (
 	ocpv1 "github.com/openshift/api/config/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	lifecycleapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
@@ -27,6 +29,61 @@ const (
 	OperatorPausedAnnotation = "kubevirt.io/operator.paused"
 )
 
+// TemplateValidatorMode defines how the template validator handles VMs that violate validation rules.
+// +kubebuilder:validation:Enum=Enforce;Audit
+type TemplateValidatorMode string
+
+const (
+	// TemplateValidatorModeEnforce rejects VMs that violate validation rules.
+	TemplateValidatorModeEnforce TemplateValidatorMode = "Enforce"
+
+	// TemplateValidatorModeAudit admits VMs that violate validation rules and only records the violations.
+	TemplateValidatorModeAudit TemplateValidatorMode = "Audit"
+)
+
+// AnnotationOverridesPolicy defines how the template validator handles annotations that skip or replace
+// validation rules, when they are set by users who are not allowed to override validation rules.
+// +kubebuilder:validation:Enum=Allow;Ignore;Reject
+type AnnotationOverridesPolicy string
+
+const (
+	// AnnotationOverridesAllow honors the annotations regardless of the user who set them.
+	AnnotationOverridesAllow AnnotationOverridesPolicy = "Allow"
+
+	// AnnotationOverridesIgnore validates VMIs created directly as if the annotations set by unauthorized users
+	// were not present. VMs with such annotations are rejected, because the annotations are persisted.
+	AnnotationOverridesIgnore AnnotationOverridesPolicy = "Ignore"
+
+	// AnnotationOverridesReject rejects VMs with annotations set by unauthorized users.
+	AnnotationOverridesReject AnnotationOverridesPolicy = "Reject"
+)
+
+// MissingTemplatePolicy defines how the template validator handles VMs referencing a template that does not exist.
+// +kubebuilder:validation:Enum=Warn;Reject
+type MissingTemplatePolicy string
+
+const (
+	// MissingTemplateWarn admits VMs referencing a template that does not exist, with a warning.
+	MissingTemplateWarn MissingTemplatePolicy = "Warn"
+
+	// MissingTemplateReject rejects VMs referencing a template that does not exist.
+	MissingTemplateReject MissingTemplatePolicy = "Reject"
+)
+
+// OrphanedGoldenImagesPolicy defines how the SSP Operator handles golden image volumes
+// that are not used by any DataSource or DataImportCron.
+// +kubebuilder:validation:Enum=Report;Delete
+type OrphanedGoldenImagesPolicy string
+
+const (
+	// OrphanedGoldenImagesReport only reports orphaned golden image volumes in metrics.
+	OrphanedGoldenImagesReport OrphanedGoldenImagesPolicy = "Report"
+
+	// OrphanedGoldenImagesDelete reports orphaned golden image volumes and deletes the ones
+	// that are not a clone source of any DataVolume or VM.
+	OrphanedGoldenImagesDelete OrphanedGoldenImagesPolicy = "Delete"
+)
+
 type TemplateValidator struct {
 	// Replicas is the number of replicas of the template validator pod
 	//+kubebuilder:validation:Minimum=0
@@ -35,6 +92,42 @@ type TemplateValidator struct {
 
 	// Placement describes the node scheduling configuration
 	Placement *lifecycleapi.NodePlacement `json:"placement,omitempty"`
+
+	// Mode defines how VMs violating validation rules are handled.
+	// In Enforce mode, such VMs are rejected. In Audit mode, the violations
+	// are recorded as metrics, events and audit annotations, but the VMs are admitted.
+	// Individual templates can override the mode using the
+	// "template.kubevirt.io/validation-mode" annotation.
+	// Defaults to Enforce.
+	// +optional
+	Mode TemplateValidatorMode `json:"mode,omitempty"`
+
+	// AnnotationOverrides defines how the "vm.kubevirt.io/skip-validations" and
+	// "vm.kubevirt.io/validations" annotations are handled, when they are set by a user
+	// who is not allowed to override validation rules. A user is allowed, if they can
+	// perform the "override" verb on the "virtualmachinevalidations" resource
+	// of the "ssp.kubevirt.io" group in the namespace of the VM.
+	// With Allow, the annotations are honored for all users. With Ignore, a VMI
+	// created directly is validated as if the annotations were not set, and a VM is rejected,
+	// because its annotations are honored again when it is started. With Reject, both are rejected.
+	// Defaults to Allow.
+	// +optional
+	AnnotationOverrides AnnotationOverridesPolicy `json:"annotationOverrides,omitempty"`
+
+	// MissingTemplates defines how VMs are handled, when they reference a template
+	// that does not exist. The rules of such template cannot be validated.
+	// With Warn, the VM is admitted with a warning. With Reject, the VM is rejected.
+	// The template reference is checked when a VM is created, or when the reference changes.
+	// Defaults to Warn.
+	// +optional
+	MissingTemplates MissingTemplatePolicy `json:"missingTemplates,omitempty"`
+
+	// StructuredRejectionCauses adds a cause of type "ValidationRuleViolation" for each rule
+	// violated by a rejected VM. The message of such cause is a JSON object describing the rule,
+	// the values found in the VM and the expected values. The causes of type "FieldValueInvalid"
+	// with human-readable messages are returned in any case.
+	// +optional
+	StructuredRejectionCauses bool `json:"structuredRejectionCauses,omitempty"`
 }
 
 type CommonTemplates struct {
@@ -43,8 +136,186 @@ type CommonTemplates struct {
 	//+kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	Namespace string `json:"namespace"`
 
+	// GoldenImagesNamespace is the k8s namespace where the golden images, their DataSources
+	// and DataImportCrons are managed. If not set, the default golden images namespace is used.
+	//+kubebuilder:validation:MaxLength=63
+	//+kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	GoldenImagesNamespace string `json:"goldenImagesNamespace,omitempty"`
+
 	// DataImportCronTemplates defines a list of DataImportCrons managed by the SSP Operator.
 	DataImportCronTemplates []DataImportCronTemplate `json:"dataImportCronTemplates,omitempty"`
+
+	// DisabledDataImportCrons is a list of DataImportCronTemplates for which the DataImportCron is not created.
+	// DataSources managed by disabled DataImportCrons point to the golden image PVC, which needs to be provided manually.
+	DisabledDataImportCrons []DisabledDataImportCron `json:"disabledDataImportCrons,omitempty"`
+
+	// DataSourceAliases define DataSources in the golden images namespace that refer
+	// to other DataSources managed by the SSP Operator.
+	DataSourceAliases []DataSourceAlias `json:"dataSourceAliases,omitempty"`
+
+	// DataSourceArchitectureOverrides define architectures of individual DataSources,
+	// if multiple architectures are enabled.
+	DataSourceArchitectureOverrides []DataSourceArchitectureOverride `json:"dataSourceArchitectureOverrides,omitempty"`
+
+	// GoldenImageStoragePolicy defines storage settings of golden images imported
+	// by all DataImportCrons managed by the SSP Operator.
+	GoldenImageStoragePolicy *GoldenImageStoragePolicy `json:"goldenImageStoragePolicy,omitempty"`
+
+	// GoldenImageAlerts configures alerts for golden images that are stale or not ready.
+	GoldenImageAlerts *GoldenImageAlerts `json:"goldenImageAlerts,omitempty"`
+
+	// GoldenImageRegistryMirrors define mirrors used instead of the registries
+	// in the sources of all DataImportCrons managed by the SSP Operator.
+	GoldenImageRegistryMirrors []GoldenImageRegistryMirror `json:"goldenImageRegistryMirrors,omitempty"`
+
+	// GoldenImageImportWindow limits the time of day when DataImportCrons managed by the SSP Operator
+	// import golden images. The imports are spread across the window.
+	// Only schedules that run at a single minute and hour of the day are changed.
+	GoldenImageImportWindow *GoldenImageImportWindow `json:"goldenImageImportWindow,omitempty"`
+
+	// OrphanedGoldenImages defines how PVCs and VolumeSnapshots in the golden images namespace
+	// that are not used by any DataSource or DataImportCron are handled.
+	// With Report, they are only reported in metrics. With Delete, they are also deleted,
+	// if they are older than one hour and are not a clone source of any DataVolume or VM.
+	// Defaults to Report.
+	// +optional
+	OrphanedGoldenImages OrphanedGoldenImagesPolicy `json:"orphanedGoldenImages,omitempty"`
+
+	// GoldenImageAccess defines subjects that can view and clone golden images.
+	// If not set, all authenticated users and service accounts can view and clone golden images.
+	GoldenImageAccess *GoldenImageAccess `json:"goldenImageAccess,omitempty"`
+}
+
+// GoldenImageAccess defines who can access golden images in the golden images namespace.
+type GoldenImageAccess struct {
+	// Viewers are subjects that can view golden images, their DataSources and DataImportCrons,
+	// and clone golden images to other namespaces. If empty, no subject is granted access.
+	Viewers []GoldenImageSubject `json:"viewers,omitempty"`
+}
+
+// GoldenImageSubject identifies a user, a group or a service account.
+type GoldenImageSubject struct {
+	// Kind is the kind of the subject.
+	//+kubebuilder:validation:Enum=User;Group;ServiceAccount
+	Kind string `json:"kind"`
+
+	// Name is the name of the subject.
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the ServiceAccount. It is required for ServiceAccount subjects.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// GoldenImageImportWindow defines the time of day when golden images are imported.
+// The hours are in the time zone of the CDI controller.
+type GoldenImageImportWindow struct {
+	// StartHour is the hour of the day when the window starts.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=23
+	StartHour int32 `json:"startHour"`
+
+	// Hours is the length of the window in hours.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=24
+	Hours int32 `json:"hours"`
+}
+
+// GoldenImageRegistryMirror defines a mirror of images in a registry.
+type GoldenImageRegistryMirror struct {
+	// Source is the prefix of the image URLs that are replaced by the mirror,
+	// without the transport, for example "quay.io/containerdisks".
+	//+kubebuilder:validation:MinLength=1
+	Source string `json:"source"`
+
+	// Mirror is the prefix that replaces the source prefix, for example "mirror.example.com/containerdisks".
+	//+kubebuilder:validation:MinLength=1
+	Mirror string `json:"mirror"`
+
+	// SecretRef is the name of the Secret with credentials for the mirror.
+	// If not set, the Secret defined in the DataImportCron template is used.
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// CertConfigMap is the name of the ConfigMap with the CA certificate of the mirror.
+	// If not set, the ConfigMap defined in the DataImportCron template is used.
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+}
+
+// DataSourceAlias defines a DataSource that refers to another DataSource, like a version channel.
+type DataSourceAlias struct {
+	// Name is the name of the alias DataSource, for example "rhel-latest".
+	//+kubebuilder:validation:MaxLength=253
+	//+kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	Name string `json:"name"`
+
+	// DataSource is the name of the referenced DataSource, for example "rhel9".
+	//+kubebuilder:validation:MinLength=1
+	DataSource string `json:"dataSource"`
+}
+
+// DataSourceArchitectureOverride defines the architectures of a DataSource.
+type DataSourceArchitectureOverride struct {
+	// Name is the name of the DataSource without the architecture suffix, for example "fedora".
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Architectures limits the architectures for which the DataSource and its DataImportCron are created.
+	// If not set, all architectures of the cluster supported by the DataSource are used.
+	Architectures []string `json:"architectures,omitempty"`
+
+	// DefaultArchitecture is the architecture of the DataSource, which is referenced
+	// by the DataSource without the architecture suffix.
+	// If not set, the first architecture of the cluster supported by the DataSource is used.
+	DefaultArchitecture string `json:"defaultArchitecture,omitempty"`
+}
+
+// DisabledDataImportCron identifies a DataImportCron that is not created by the SSP Operator.
+type DisabledDataImportCron struct {
+	// Name is the name of the DataImportCronTemplate.
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Architecture disables the DataImportCron only for this architecture.
+	// If not set, the DataImportCron is disabled for all architectures.
+	// It is used only when multiple architectures are enabled.
+	Architecture string `json:"architecture,omitempty"`
+}
+
+// GoldenImageAlerts configures when alerts are fired for golden images.
+type GoldenImageAlerts struct {
+	// StalePeriod is the time since a golden image was last known to be up to date with its source
+	// by its DataImportCron, after which the golden image is reported as stale. Defaults to 168h.
+	StalePeriod *metav1.Duration `json:"stalePeriod,omitempty"`
+
+	// NotReadyPeriod is the time a DataSource can be not ready, before it is reported. Defaults to 1h.
+	NotReadyPeriod *metav1.Duration `json:"notReadyPeriod,omitempty"`
+}
+
+// GoldenImageStoragePolicy defines storage settings of golden images.
+// The settings are used only if the DataImportCron template does not define them.
+type GoldenImageStoragePolicy struct {
+	// StorageClassName is the name of the storage class used for golden images.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// AccessModes are the access modes of golden image volumes.
+	AccessModes []core.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+
+	// VolumeMode is the volume mode of golden image volumes.
+	VolumeMode *core.PersistentVolumeMode `json:"volumeMode,omitempty"`
+
+	// SizeOverrides define the size of golden images for DataSources matching a name pattern.
+	// The first matching override is used.
+	SizeOverrides []GoldenImageSizeOverride `json:"sizeOverrides,omitempty"`
+}
+
+// GoldenImageSizeOverride defines the size of golden images for DataSources matching a name pattern.
+type GoldenImageSizeOverride struct {
+	// DataSourcePattern is a glob pattern matched against the name of the managed DataSource, for example "win*".
+	//+kubebuilder:validation:MinLength=1
+	DataSourcePattern string `json:"dataSourcePattern"`
+
+	// Size is the requested size of the golden image volume.
+	Size resource.Quantity `json:"size"`
 }
 
 type Cluster struct {
@@ -0,0 +1,114 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ValidationRuleType is the type of the validation rule
+// +kubebuilder:validation:Enum=integer;string;value-set;regex
+type ValidationRuleType string
+
+// ValidationRule has the same format as the rules in the "validations" annotation of templates.
+//
+// The Path, Valid, Min, Max, MinLength, MaxLength and Values fields
+// can contain a JSONPath prefixed with "jsonpath::".
+type ValidationRule struct {
+	// Name of the rule, it has to be unique in the policy
+	Name string `json:"name"`
+
+	// Rule is the type of the rule
+	Rule ValidationRuleType `json:"rule"`
+
+	// Path is the JSONPath of the VM field that is checked, prefixed with "jsonpath::"
+	Path string `json:"path"`
+
+	// Message is shown to the user when the rule is violated
+	Message string `json:"message"`
+
+	// Valid is a JSONPath, the rule is applied only if it points to an existing field
+	// +optional
+	Valid string `json:"valid,omitempty"`
+
+	// JustWarning makes the rule only log a warning if it is violated
+	// +optional
+	JustWarning bool `json:"justWarning,omitempty"`
+
+	// Values is the list of allowed values for the value-set rule
+	// +optional
+	Values []string `json:"values,omitempty"`
+
+	// Min is the minimal value for the integer rule
+	// +optional
+	Min *intstr.IntOrString `json:"min,omitempty"`
+
+	// Max is the maximal value for the integer rule
+	// +optional
+	Max *intstr.IntOrString `json:"max,omitempty"`
+
+	// MinLength is the minimal length for the string rule
+	// +optional
+	MinLength *intstr.IntOrString `json:"minLength,omitempty"`
+
+	// MaxLength is the maximal length for the string rule
+	// +optional
+	MaxLength *intstr.IntOrString `json:"maxLength,omitempty"`
+
+	// Regex is the regular expression for the regex rule
+	// +optional
+	Regex string `json:"regex,omitempty"`
+}
+
+// VirtualMachineValidationPolicySpec defines the rules for VMs in the namespace of the policy
+type VirtualMachineValidationPolicySpec struct {
+	// Selector selects the VMs that the policy applies to.
+	// If it is not set, the policy applies to all VMs in the namespace.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Rules are the validation rules applied to the selected VMs
+	// +listType=map
+	// +listMapKey=name
+	Rules []ValidationRule `json:"rules"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=vmvalidationpolicy;vmvalidationpolicies
+
+// VirtualMachineValidationPolicy defines validation rules that are checked by the template validator
+// for VMs in its namespace, independently of the template the VMs were created from.
+type VirtualMachineValidationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineValidationPolicySpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineValidationPolicyList contains a list of VirtualMachineValidationPolicy
+type VirtualMachineValidationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineValidationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VirtualMachineValidationPolicy{}, &VirtualMachineValidationPolicyList{})
+}
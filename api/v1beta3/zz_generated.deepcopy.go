@@ -22,7 +22,10 @@ package v1beta3
 
 import (
 	"github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -60,6 +63,48 @@ func (in *CommonTemplates) DeepCopyInto(out *CommonTemplates) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DisabledDataImportCrons != nil {
+		in, out := &in.DisabledDataImportCrons, &out.DisabledDataImportCrons
+		*out = make([]DisabledDataImportCron, len(*in))
+		copy(*out, *in)
+	}
+	if in.DataSourceAliases != nil {
+		in, out := &in.DataSourceAliases, &out.DataSourceAliases
+		*out = make([]DataSourceAlias, len(*in))
+		copy(*out, *in)
+	}
+	if in.DataSourceArchitectureOverrides != nil {
+		in, out := &in.DataSourceArchitectureOverrides, &out.DataSourceArchitectureOverrides
+		*out = make([]DataSourceArchitectureOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GoldenImageStoragePolicy != nil {
+		in, out := &in.GoldenImageStoragePolicy, &out.GoldenImageStoragePolicy
+		*out = new(GoldenImageStoragePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GoldenImageAlerts != nil {
+		in, out := &in.GoldenImageAlerts, &out.GoldenImageAlerts
+		*out = new(GoldenImageAlerts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GoldenImageRegistryMirrors != nil {
+		in, out := &in.GoldenImageRegistryMirrors, &out.GoldenImageRegistryMirrors
+		*out = make([]GoldenImageRegistryMirror, len(*in))
+		copy(*out, *in)
+	}
+	if in.GoldenImageImportWindow != nil {
+		in, out := &in.GoldenImageImportWindow, &out.GoldenImageImportWindow
+		*out = new(GoldenImageImportWindow)
+		**out = **in
+	}
+	if in.GoldenImageAccess != nil {
+		in, out := &in.GoldenImageAccess, &out.GoldenImageAccess
+		*out = new(GoldenImageAccess)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonTemplates.
@@ -89,6 +134,199 @@ func (in *DataImportCronTemplate) DeepCopy() *DataImportCronTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourceAlias) DeepCopyInto(out *DataSourceAlias) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceAlias.
+func (in *DataSourceAlias) DeepCopy() *DataSourceAlias {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourceAlias)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourceArchitectureOverride) DeepCopyInto(out *DataSourceArchitectureOverride) {
+	*out = *in
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceArchitectureOverride.
+func (in *DataSourceArchitectureOverride) DeepCopy() *DataSourceArchitectureOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourceArchitectureOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisabledDataImportCron) DeepCopyInto(out *DisabledDataImportCron) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisabledDataImportCron.
+func (in *DisabledDataImportCron) DeepCopy() *DisabledDataImportCron {
+	if in == nil {
+		return nil
+	}
+	out := new(DisabledDataImportCron)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoldenImageAccess) DeepCopyInto(out *GoldenImageAccess) {
+	*out = *in
+	if in.Viewers != nil {
+		in, out := &in.Viewers, &out.Viewers
+		*out = make([]GoldenImageSubject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoldenImageAccess.
+func (in *GoldenImageAccess) DeepCopy() *GoldenImageAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(GoldenImageAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoldenImageAlerts) DeepCopyInto(out *GoldenImageAlerts) {
+	*out = *in
+	if in.StalePeriod != nil {
+		in, out := &in.StalePeriod, &out.StalePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.NotReadyPeriod != nil {
+		in, out := &in.NotReadyPeriod, &out.NotReadyPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoldenImageAlerts.
+func (in *GoldenImageAlerts) DeepCopy() *GoldenImageAlerts {
+	if in == nil {
+		return nil
+	}
+	out := new(GoldenImageAlerts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoldenImageImportWindow) DeepCopyInto(out *GoldenImageImportWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoldenImageImportWindow.
+func (in *GoldenImageImportWindow) DeepCopy() *GoldenImageImportWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(GoldenImageImportWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoldenImageRegistryMirror) DeepCopyInto(out *GoldenImageRegistryMirror) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoldenImageRegistryMirror.
+func (in *GoldenImageRegistryMirror) DeepCopy() *GoldenImageRegistryMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(GoldenImageRegistryMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoldenImageSizeOverride) DeepCopyInto(out *GoldenImageSizeOverride) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoldenImageSizeOverride.
+func (in *GoldenImageSizeOverride) DeepCopy() *GoldenImageSizeOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(GoldenImageSizeOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoldenImageStoragePolicy) DeepCopyInto(out *GoldenImageStoragePolicy) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeMode != nil {
+		in, out := &in.VolumeMode, &out.VolumeMode
+		*out = new(corev1.PersistentVolumeMode)
+		**out = **in
+	}
+	if in.SizeOverrides != nil {
+		in, out := &in.SizeOverrides, &out.SizeOverrides
+		*out = make([]GoldenImageSizeOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoldenImageStoragePolicy.
+func (in *GoldenImageStoragePolicy) DeepCopy() *GoldenImageStoragePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GoldenImageStoragePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoldenImageSubject) DeepCopyInto(out *GoldenImageSubject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoldenImageSubject.
+func (in *GoldenImageSubject) DeepCopy() *GoldenImageSubject {
+	if in == nil {
+		return nil
+	}
+	out := new(GoldenImageSubject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSP) DeepCopyInto(out *SSP) {
 	*out = *in
@@ -243,3 +481,128 @@ func (in *TokenGenerationService) DeepCopy() *TokenGenerationService {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationRule) DeepCopyInto(out *ValidationRule) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MinLength != nil {
+		in, out := &in.MinLength, &out.MinLength
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxLength != nil {
+		in, out := &in.MaxLength, &out.MaxLength
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationRule.
+func (in *ValidationRule) DeepCopy() *ValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineValidationPolicy) DeepCopyInto(out *VirtualMachineValidationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineValidationPolicy.
+func (in *VirtualMachineValidationPolicy) DeepCopy() *VirtualMachineValidationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineValidationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineValidationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineValidationPolicyList) DeepCopyInto(out *VirtualMachineValidationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineValidationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineValidationPolicyList.
+func (in *VirtualMachineValidationPolicyList) DeepCopy() *VirtualMachineValidationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineValidationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineValidationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineValidationPolicySpec) DeepCopyInto(out *VirtualMachineValidationPolicySpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ValidationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineValidationPolicySpec.
+func (in *VirtualMachineValidationPolicySpec) DeepCopy() *VirtualMachineValidationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineValidationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,15 @@
+package common
+
+import (
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal"
+)
+
+// GetGoldenImagesNamespace returns the namespace where golden images are managed.
+// If the namespace is not set in the SSP spec, internal.GoldenImagesNamespace is used.
+func GetGoldenImagesNamespace(sspSpec *ssp.SSPSpec) string {
+	if sspSpec.CommonTemplates.GoldenImagesNamespace != "" {
+		return sspSpec.CommonTemplates.GoldenImagesNamespace
+	}
+	return internal.GoldenImagesNamespace
+}
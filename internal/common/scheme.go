@@ -2,6 +2,7 @@ package common
 
 import (
 	osconfv1 "github.com/openshift/api/config/v1"
+	templatev1 "github.com/openshift/api/template/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	internalmeta "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -10,6 +11,7 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	kubevirtv1 "kubevirt.io/api/core/v1"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
 
 	sspv1beta2 "kubevirt.io/ssp-operator/api/v1beta2"
 	sspv1beta3 "kubevirt.io/ssp-operator/api/v1beta3"
@@ -28,6 +30,8 @@ func init() {
 	utilruntime.Must(sspv1beta3.AddToScheme(Scheme))
 	utilruntime.Must(osconfv1.Install(Scheme))
 	utilruntime.Must(kubevirtv1.AddToScheme(Scheme))
+	utilruntime.Must(templatev1.Install(Scheme))
+	utilruntime.Must(instancetypev1beta1.AddToScheme(Scheme))
 }
 
 // AddConversionFunctions is useful in operand unit tests only
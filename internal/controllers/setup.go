@@ -72,6 +72,11 @@ func CreateControllers(ctx context.Context, apiReader client.Reader, olmDeployme
 	}
 
 	if runningOnOpenShift {
+		operatorUsername, err := env.GetOperatorUsername()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get operator username: %w", err)
+		}
+
 		templatesOperand, err := common_templates.New(templates)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create common templates operand: %w", err)
@@ -79,7 +84,7 @@ func CreateControllers(ctx context.Context, apiReader client.Reader, olmDeployme
 
 		sspOperands = append(sspOperands,
 			metrics.New(),
-			template_validator.New(),
+			template_validator.New(operatorUsername),
 			templatesOperand,
 			vm_console_proxy.New(vmConsoleProxyBundle),
 		)
@@ -102,6 +107,7 @@ func CreateControllers(ctx context.Context, apiReader client.Reader, olmDeployme
 		serviceController,
 		NewWebhookConfigurationController(),
 		NewVmController(),
+		NewVmValidationSummaryController(runningOnOpenShift),
 		NewSspController(infrastructureTopology, sspOperands, olmDeployment, sspServiceHostname),
 	}, nil
 }
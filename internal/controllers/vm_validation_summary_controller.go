@@ -0,0 +1,317 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	templatev1 "github.com/openshift/api/template/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/api/instancetype"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	crd_watch "kubevirt.io/ssp-operator/internal/crd-watch"
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
+	validating "kubevirt.io/ssp-operator/internal/template-validator/webhooks"
+)
+
+// +kubebuilder:rbac:groups=kubevirt.io,resources=virtualmachines,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=template.openshift.io,resources=templates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ssp.kubevirt.io,resources=virtualmachinevalidationpolicies,verbs=list;watch
+// +kubebuilder:rbac:groups=instancetype.kubevirt.io,resources=virtualmachineinstancetypes;virtualmachineclusterinstancetypes;virtualmachinepreferences;virtualmachineclusterpreferences,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get
+
+const vmValidationSummaryControllerName = "vm-validation-summary-controller"
+
+// vmValidationSummaryController evaluates the validation rules of VMs and stores
+// the summary of the result in an annotation of the VM. Only VMs that have validation rules,
+// reference a missing template or skip validation get the annotation.
+//
+// Templates and instancetypes are read without a cache, so the operator does not keep
+// all of them in memory. Only metadata of templates is watched, to find the VMs
+// whose rules may have changed.
+type vmValidationSummaryController struct {
+	log logr.Logger
+
+	client    client.Client
+	apiReader client.Reader
+
+	watchTemplates    bool
+	policiesInstalled bool
+}
+
+var _ Controller = &vmValidationSummaryController{}
+
+var _ reconcile.Reconciler = &vmValidationSummaryController{}
+
+// NewVmValidationSummaryController creates the controller. Templates are watched only
+// if watchTemplates is true, because the API exists only on OpenShift.
+func NewVmValidationSummaryController(watchTemplates bool) Controller {
+	return &vmValidationSummaryController{
+		log:            ctrl.Log.WithName("controllers").WithName("VirtualMachineValidationSummary"),
+		watchTemplates: watchTemplates,
+	}
+}
+
+func (v *vmValidationSummaryController) Name() string {
+	return vmValidationSummaryControllerName
+}
+
+func (v *vmValidationSummaryController) AddToManager(mgr ctrl.Manager, crdList crd_watch.CrdList) error {
+	v.client = mgr.GetClient()
+	v.apiReader = mgr.GetAPIReader()
+
+	if !crdList.CrdExists(getVmCrd()) {
+		// If VM CRD doesn't exist, this controller does nothing
+		return nil
+	}
+	v.policiesInstalled = crdList.CrdExists(getValidationPolicyCrd())
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		Named(vmValidationSummaryControllerName).
+		// Status changes do not change the result of the validation
+		For(&kubevirtv1.VirtualMachine{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		)))
+
+	if v.watchTemplates {
+		controllerBuilder = controllerBuilder.Watches(&templatev1.Template{},
+			handler.EnqueueRequestsFromMapFunc(v.vmsForTemplate),
+			builder.OnlyMetadata)
+	}
+	if v.policiesInstalled {
+		controllerBuilder = controllerBuilder.Watches(&ssp.VirtualMachineValidationPolicy{},
+			handler.EnqueueRequestsFromMapFunc(v.vmsForPolicy))
+	}
+
+	return controllerBuilder.Complete(v)
+}
+
+func (v *vmValidationSummaryController) RequiredCrds() []string {
+	return []string{getVmCrd()}
+}
+
+func (v *vmValidationSummaryController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	vm := &kubevirtv1.VirtualMachine{}
+	if err := v.client.Get(ctx, req.NamespacedName, vm); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("could not get VM %s/%s: %w", req.Namespace, req.Name, err)
+	}
+
+	if vm.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	sources := validating.RuleSources{
+		Templates: &clientKeyGetter{
+			ctx:       ctx,
+			client:    v.apiReader,
+			newObject: func() client.Object { return &templatev1.Template{} },
+		},
+		Instancetypes: &clientInstancetypeStores{
+			ctx:    ctx,
+			client: v.apiReader,
+		},
+	}
+	if v.policiesInstalled {
+		sources.Policies = &clientPolicyIndexer{
+			ctx:    ctx,
+			client: v.client,
+		}
+	}
+
+	summary, err := validating.SummarizeVm(vm, sources)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not evaluate validation rules of VM %s/%s: %w", req.Namespace, req.Name, err)
+	}
+
+	value, hasSummary := vm.Annotations[labels.VmValidationSummaryAnnotationKey]
+	if summary.Rules == 0 && !summary.MissingTemplate && !summary.Skipped {
+		// VMs without rules do not need a summary
+		if !hasSummary {
+			return ctrl.Result{}, nil
+		}
+		patch := client.MergeFrom(vm.DeepCopy())
+		delete(vm.Annotations, labels.VmValidationSummaryAnnotationKey)
+
+		v.log.V(1).Info("Removing validation summary", "vm", req.NamespacedName)
+		return ctrl.Result{}, v.patchVm(ctx, vm, patch)
+	}
+
+	summaryJson, err := json.Marshal(summary)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if hasSummary && value == string(summaryJson) {
+		return ctrl.Result{}, nil
+	}
+
+	patch := client.MergeFrom(vm.DeepCopy())
+	if vm.Annotations == nil {
+		vm.Annotations = map[string]string{}
+	}
+	vm.Annotations[labels.VmValidationSummaryAnnotationKey] = string(summaryJson)
+
+	v.log.V(1).Info("Updating validation summary", "vm", req.NamespacedName, "summary", string(summaryJson))
+	return ctrl.Result{}, v.patchVm(ctx, vm, patch)
+}
+
+func (v *vmValidationSummaryController) patchVm(ctx context.Context, vm *kubevirtv1.VirtualMachine, patch client.Patch) error {
+	if err := v.client.Patch(ctx, vm, patch); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("could not update validation summary of VM %s/%s: %w", vm.Namespace, vm.Name, err)
+	}
+	return nil
+}
+
+// vmsForTemplate returns requests for the VMs that reference the template using labels.
+func (v *vmValidationSummaryController) vmsForTemplate(ctx context.Context, obj client.Object) []reconcile.Request {
+	vms := &kubevirtv1.VirtualMachineList{}
+	if err := v.client.List(ctx, vms, client.MatchingLabels{labels.AnnotationTemplateNameKey: obj.GetName()}); err != nil {
+		v.log.Error(err, "Could not list VMs using template", "template", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range vms.Items {
+		templateKeys := labels.GetTemplateKeys(&vms.Items[i])
+		templateKey := templateKeys.Get()
+		if templateKey.Name != obj.GetName() || templateKey.AnyNamespace() != obj.GetNamespace() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&vms.Items[i])})
+	}
+	return requests
+}
+
+// vmsForPolicy returns requests for all VMs in the namespace of the policy. All of them are returned,
+// because a VM that is not selected anymore may still have the rules of the policy in its summary.
+func (v *vmValidationSummaryController) vmsForPolicy(ctx context.Context, obj client.Object) []reconcile.Request {
+	vms := &kubevirtv1.VirtualMachineList{}
+	if err := v.client.List(ctx, vms, client.InNamespace(obj.GetNamespace())); err != nil {
+		v.log.Error(err, "Could not list VMs in namespace of validation policy", "policy", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(vms.Items))
+	for i := range vms.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&vms.Items[i])})
+	}
+	return requests
+}
+
+func getValidationPolicyCrd() string {
+	return "virtualmachinevalidationpolicies." + ssp.GroupVersion.Group
+}
+
+// clientKeyGetter implements cache.KeyGetter by reading objects using the client.
+// Objects whose CRD is not installed do not exist.
+type clientKeyGetter struct {
+	ctx       context.Context
+	client    client.Reader
+	newObject func() client.Object
+}
+
+var _ cache.KeyGetter = &clientKeyGetter{}
+
+func (c *clientKeyGetter) GetByKey(key string) (interface{}, bool, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	obj := c.newObject()
+	if err := c.client.Get(c.ctx, client.ObjectKey{Namespace: namespace, Name: name}, obj); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return obj, true, nil
+}
+
+// clientPolicyIndexer lists VirtualMachineValidationPolicies by namespace using the client.
+type clientPolicyIndexer struct {
+	ctx    context.Context
+	client client.Reader
+}
+
+var _ validating.PolicyIndexer = &clientPolicyIndexer{}
+
+func (c *clientPolicyIndexer) ByIndex(indexName, indexedValue string) ([]interface{}, error) {
+	if indexName != cache.NamespaceIndex {
+		return nil, fmt.Errorf("unsupported index: %s", indexName)
+	}
+
+	policies := &ssp.VirtualMachineValidationPolicyList{}
+	if err := c.client.List(c.ctx, policies, client.InNamespace(indexedValue)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	result := make([]interface{}, 0, len(policies.Items))
+	for i := range policies.Items {
+		result = append(result, &policies.Items[i])
+	}
+	return result, nil
+}
+
+// clientInstancetypeStores reads instancetypes and preferences using the client.
+type clientInstancetypeStores struct {
+	ctx    context.Context
+	client client.Reader
+}
+
+var _ validating.InstancetypeStores = &clientInstancetypeStores{}
+
+var instancetypeObjects = map[string]func() client.Object{
+	instancetype.PluralResourceName:                  func() client.Object { return &instancetypev1beta1.VirtualMachineInstancetype{} },
+	instancetype.ClusterPluralResourceName:           func() client.Object { return &instancetypev1beta1.VirtualMachineClusterInstancetype{} },
+	instancetype.PluralPreferenceResourceName:        func() client.Object { return &instancetypev1beta1.VirtualMachinePreference{} },
+	instancetype.ClusterPluralPreferenceResourceName: func() client.Object { return &instancetypev1beta1.VirtualMachineClusterPreference{} },
+}
+
+func (c *clientInstancetypeStores) InstancetypeStore(resource string) cache.KeyGetter {
+	newObject, ok := instancetypeObjects[resource]
+	if !ok {
+		return cache.NewStore(cache.MetaNamespaceKeyFunc)
+	}
+	return &clientKeyGetter{
+		ctx:       c.ctx,
+		client:    c.client,
+		newObject: newObject,
+	}
+}
+
+func (c *clientInstancetypeStores) ControllerRevision(namespace, name string) (*appsv1.ControllerRevision, error) {
+	revision := &appsv1.ControllerRevision{}
+	err := c.client.Get(c.ctx, client.ObjectKey{Namespace: namespace, Name: name}, revision)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
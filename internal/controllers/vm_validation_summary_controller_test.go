@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	templatev1 "github.com/openshift/api/template/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal/common"
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
+	validating "kubevirt.io/ssp-operator/internal/template-validator/webhooks"
+)
+
+var _ = Describe("VM validation summary controller", func() {
+	const (
+		namespace    = "test-namespace"
+		templateName = "test-template"
+	)
+
+	var (
+		vm             *kubevirtv1.VirtualMachine
+		fakeClient     client.Client
+		testController *vmValidationSummaryController
+		testRequest    reconcile.Request
+	)
+
+	getSummary := func() *validating.ValidationSummary {
+		updatedVm := &kubevirtv1.VirtualMachine{}
+		Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(vm), updatedVm)).To(Succeed())
+		Expect(updatedVm.Annotations).To(HaveKey(labels.VmValidationSummaryAnnotationKey))
+
+		summary := &validating.ValidationSummary{}
+		Expect(json.Unmarshal([]byte(updatedVm.Annotations[labels.VmValidationSummaryAnnotationKey]), summary)).To(Succeed())
+		return summary
+	}
+
+	BeforeEach(func() {
+		vm = &kubevirtv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vm",
+				Namespace: namespace,
+				Labels: map[string]string{
+					labels.AnnotationTemplateNameKey:      templateName,
+					labels.AnnotationTemplateNamespaceKey: namespace,
+				},
+			},
+			Spec: kubevirtv1.VirtualMachineSpec{
+				Template: &kubevirtv1.VirtualMachineInstanceTemplateSpec{
+					Spec: kubevirtv1.VirtualMachineInstanceSpec{
+						Domain: kubevirtv1.DomainSpec{
+							CPU: &kubevirtv1.CPU{Cores: 4},
+						},
+					},
+				},
+			},
+		}
+
+		fakeClient = fake.NewClientBuilder().WithScheme(common.Scheme).WithObjects(vm).Build()
+
+		testController = NewVmValidationSummaryController(true).(*vmValidationSummaryController)
+		testController.client = fakeClient
+		testController.apiReader = fakeClient
+		testController.policiesInstalled = true
+
+		testRequest = reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(vm),
+		}
+	})
+
+	It("should write summary of template rules", func() {
+		template := &templatev1.Template{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      templateName,
+				Namespace: namespace,
+				Annotations: map[string]string{
+					labels.AnnotationValidationKey: `[{
+						"name": "LimitCores",
+						"path": "jsonpath::.spec.domain.cpu.cores",
+						"message": "Core amount not within range",
+						"rule": "integer",
+						"min": 1,
+						"max": 2
+					}]`,
+				},
+			},
+		}
+		Expect(fakeClient.Create(context.Background(), template)).To(Succeed())
+
+		_, err := testController.Reconcile(context.Background(), testRequest)
+		Expect(err).ToNot(HaveOccurred())
+
+		summary := getSummary()
+		Expect(summary.Template).To(Equal(namespace + "/" + templateName))
+		Expect(summary.MissingTemplate).To(BeFalse())
+		Expect(summary.Rules).To(Equal(1))
+		Expect(summary.Violations).To(ConsistOf("LimitCores"))
+	})
+
+	It("should write summary including validation policies", func() {
+		policy := &ssp.VirtualMachineValidationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-policy",
+				Namespace: namespace,
+			},
+			Spec: ssp.VirtualMachineValidationPolicySpec{
+				Rules: []ssp.ValidationRule{{
+					Name:    "LimitCores",
+					Rule:    "integer",
+					Path:    "jsonpath::.spec.domain.cpu.cores",
+					Message: "Core amount not within range",
+					Min:     ptr.To(intstr.FromInt32(1)),
+					Max:     ptr.To(intstr.FromInt32(8)),
+				}},
+			},
+		}
+		Expect(fakeClient.Create(context.Background(), policy)).To(Succeed())
+
+		_, err := testController.Reconcile(context.Background(), testRequest)
+		Expect(err).ToNot(HaveOccurred())
+
+		summary := getSummary()
+		Expect(summary.MissingTemplate).To(BeTrue())
+		Expect(summary.Rules).To(Equal(1))
+		Expect(summary.Violations).To(BeEmpty())
+	})
+
+	It("should not update VM if summary did not change", func() {
+		_, err := testController.Reconcile(context.Background(), testRequest)
+		Expect(err).ToNot(HaveOccurred())
+
+		updatedVm := &kubevirtv1.VirtualMachine{}
+		Expect(fakeClient.Get(context.Background(), testRequest.NamespacedName, updatedVm)).To(Succeed())
+
+		_, err = testController.Reconcile(context.Background(), testRequest)
+		Expect(err).ToNot(HaveOccurred())
+
+		unchangedVm := &kubevirtv1.VirtualMachine{}
+		Expect(fakeClient.Get(context.Background(), testRequest.NamespacedName, unchangedVm)).To(Succeed())
+		Expect(unchangedVm.ResourceVersion).To(Equal(updatedVm.ResourceVersion))
+	})
+
+	It("should not write summary for VM without rules", func() {
+		vm.Labels = nil
+		Expect(fakeClient.Update(context.Background(), vm)).To(Succeed())
+
+		_, err := testController.Reconcile(context.Background(), testRequest)
+		Expect(err).ToNot(HaveOccurred())
+
+		updatedVm := &kubevirtv1.VirtualMachine{}
+		Expect(fakeClient.Get(context.Background(), testRequest.NamespacedName, updatedVm)).To(Succeed())
+		Expect(updatedVm.Annotations).ToNot(HaveKey(labels.VmValidationSummaryAnnotationKey))
+		Expect(updatedVm.ResourceVersion).To(Equal(vm.ResourceVersion))
+	})
+
+	It("should remove summary when VM has no rules", func() {
+		_, err := testController.Reconcile(context.Background(), testRequest)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getSummary().MissingTemplate).To(BeTrue())
+
+		updatedVm := &kubevirtv1.VirtualMachine{}
+		Expect(fakeClient.Get(context.Background(), testRequest.NamespacedName, updatedVm)).To(Succeed())
+		updatedVm.Labels = nil
+		Expect(fakeClient.Update(context.Background(), updatedVm)).To(Succeed())
+
+		_, err = testController.Reconcile(context.Background(), testRequest)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeClient.Get(context.Background(), testRequest.NamespacedName, updatedVm)).To(Succeed())
+		Expect(updatedVm.Annotations).ToNot(HaveKey(labels.VmValidationSummaryAnnotationKey))
+	})
+
+	It("should requeue VMs using changed template", func() {
+		otherVm := vm.DeepCopy()
+		otherVm.ResourceVersion = ""
+		otherVm.Name = "other-vm"
+		otherVm.Labels[labels.AnnotationTemplateNamespaceKey] = "other-namespace"
+		Expect(fakeClient.Create(context.Background(), otherVm)).To(Succeed())
+
+		template := &templatev1.Template{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      templateName,
+				Namespace: namespace,
+			},
+		}
+
+		Expect(testController.vmsForTemplate(context.Background(), template)).To(ConsistOf(testRequest))
+	})
+
+	It("should requeue VMs in namespace of changed validation policy", func() {
+		policy := &ssp.VirtualMachineValidationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-policy",
+				Namespace: namespace,
+			},
+		}
+
+		Expect(testController.vmsForPolicy(context.Background(), policy)).To(ConsistOf(testRequest))
+
+		policy.Namespace = "other-namespace"
+		Expect(testController.vmsForPolicy(context.Background(), policy)).To(BeEmpty())
+	})
+
+	It("should ignore deleted VM", func() {
+		Expect(fakeClient.Delete(context.Background(), vm)).To(Succeed())
+
+		_, err := testController.Reconcile(context.Background(), testRequest)
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
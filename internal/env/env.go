@@ -23,6 +23,9 @@ const (
 	VmConsoleProxyImageKey    = "VM_CONSOLE_PROXY_IMAGE"
 
 	podNamespaceKey = "POD_NAMESPACE"
+
+	// operatorServiceAccountName is the name of the service account the operator runs as.
+	operatorServiceAccountName = "ssp-operator"
 )
 
 func EnvOrDefault(envName string, defVal string) string {
@@ -70,3 +73,12 @@ func GetOperatorNamespace() (string, error) {
 	}
 	return namespace, nil
 }
+
+// GetOperatorUsername returns the name of the user the operator is authenticated as.
+func GetOperatorUsername() (string, error) {
+	namespace, err := GetOperatorNamespace()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, operatorServiceAccountName), nil
+}
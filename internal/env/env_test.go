@@ -41,6 +41,17 @@ var _ = Describe("environments", func() {
 		_, err := GetOperatorNamespace()
 		Expect(err).To(MatchError(ContainSubstring("environment variable")))
 	})
+
+	It("should return username of the operator service account", func() {
+		Expect(os.Setenv(podNamespaceKey, "test-namespace")).To(Succeed())
+		defer func() {
+			Expect(os.Unsetenv(podNamespaceKey)).To(Succeed())
+		}()
+
+		username, err := GetOperatorUsername()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(username).To(Equal("system:serviceaccount:test-namespace:ssp-operator"))
+	})
 })
 
 func TestEnv(t *testing.T) {
@@ -13,5 +13,6 @@ const (
 	TemplateWorkloadLabelPrefix  = "workload.template.kubevirt.io/"
 	TemplateDeprecatedAnnotation = "template.kubevirt.io/deprecated"
 
-	TemplateDataSourceParameterName = "DATA_SOURCE_NAME"
+	TemplateDataSourceParameterName          = "DATA_SOURCE_NAME"
+	TemplateDataSourceNamespaceParameterName = "DATA_SOURCE_NAMESPACE"
 )
@@ -17,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal"
 	"kubevirt.io/ssp-operator/internal/architecture"
 	"kubevirt.io/ssp-operator/internal/common"
 	"kubevirt.io/ssp-operator/internal/env"
@@ -106,20 +107,26 @@ func (c *commonTemplates) Reconcile(request *common.Request) ([]common.Reconcile
 }
 
 func (c *commonTemplates) getTemplatesForArchs(clusterArchs []architecture.Arch, sspSpec *ssp.SSPSpec) []templatev1.Template {
+	multiArchEnabled := ptr.Deref(sspSpec.EnableMultipleArchitectures, false)
+	goldenImagesNamespace := common.GetGoldenImagesNamespace(sspSpec)
+
 	var templates []templatev1.Template
 	for _, arch := range clusterArchs {
 		templatesForArch := c.templatesByArch[arch]
-		if !ptr.Deref(sspSpec.EnableMultipleArchitectures, false) {
-			// If multi-arch is disabled, the templates are not modified.
+		if !multiArchEnabled && goldenImagesNamespace == internal.GoldenImagesNamespace {
+			// If multi-arch is disabled and the default namespace is used, the templates are not modified.
 			templates = append(templates, templatesForArch...)
 			continue
 		}
 
-		// If multi-arch is enabled, the DATA_SOURCE_NAME parameter
-		// has to point to the correct multi-arch DataSource.
 		for i := range templatesForArch {
 			templateCopy := templatesForArch[i].DeepCopy()
-			addArchSuffixToDataSourceParameter(templateCopy, arch)
+			// If multi-arch is enabled, the DATA_SOURCE_NAME parameter
+			// has to point to the correct multi-arch DataSource.
+			if multiArchEnabled {
+				addArchSuffixToDataSourceParameter(templateCopy, arch)
+			}
+			setDataSourceNamespaceParameter(templateCopy, goldenImagesNamespace)
 			templates = append(templates, *templateCopy)
 		}
 	}
@@ -136,6 +143,16 @@ func addArchSuffixToDataSourceParameter(template *templatev1.Template, arch arch
 	}
 }
 
+func setDataSourceNamespaceParameter(template *templatev1.Template, namespace string) {
+	for j := range template.Parameters {
+		param := &template.Parameters[j]
+		if param.Name == TemplateDataSourceNamespaceParameterName {
+			param.Value = namespace
+			return
+		}
+	}
+}
+
 func operatorIsUpgrading(request *common.Request) bool {
 	return request.Instance.Status.ObservedVersion != env.GetOperatorVersion()
 }
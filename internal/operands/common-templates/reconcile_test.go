@@ -20,6 +20,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal"
 	"kubevirt.io/ssp-operator/internal/architecture"
 	"kubevirt.io/ssp-operator/internal/common"
 	"kubevirt.io/ssp-operator/internal/env"
@@ -143,6 +144,31 @@ var _ = Describe("Common-Templates operand", func() {
 		Expect(value).To(Equal(float64(len(testTemplates))))
 	})
 
+	It("should set golden images namespace in templates", func() {
+		const goldenImagesNamespace = "custom-os-images"
+		request.Instance.Spec.CommonTemplates.GoldenImagesNamespace = goldenImagesNamespace
+
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, template := range testTemplates {
+			foundTemplate := &templatev1.Template{}
+			Expect(request.Client.Get(request.Context, client.ObjectKey{
+				Name:      template.Name,
+				Namespace: namespace,
+			}, foundTemplate)).To(Succeed())
+
+			Expect(foundTemplate.Parameters).To(ContainElement(templatev1.Parameter{
+				Name:  TemplateDataSourceNamespaceParameterName,
+				Value: goldenImagesNamespace,
+			}))
+			Expect(foundTemplate.Parameters).To(ContainElement(templatev1.Parameter{
+				Name:  TemplateDataSourceParameterName,
+				Value: template.Parameters[0].Value,
+			}))
+		}
+	})
+
 	Context("old templates", func() {
 		var (
 			oldTpl        templatev1.Template
@@ -479,6 +505,9 @@ func createTestTemplate(name, os, flavor, workload string, arch architecture.Arc
 		Parameters: []templatev1.Parameter{{
 			Name:  TemplateDataSourceParameterName,
 			Value: os,
+		}, {
+			Name:  TemplateDataSourceNamespaceParameterName,
+			Value: internal.GoldenImagesNamespace,
 		}},
 	}
 }
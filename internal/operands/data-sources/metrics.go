@@ -0,0 +1,139 @@
+package data_sources
+
+import (
+	"time"
+
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"kubevirt.io/ssp-operator/internal/common"
+	metrics "kubevirt.io/ssp-operator/pkg/monitoring/metrics/ssp-operator"
+)
+
+const (
+	defaultGoldenImageStalePeriod    = 7 * 24 * time.Hour
+	defaultGoldenImageNotReadyPeriod = time.Hour
+
+	// dataImportCronDesiredDigestAnnotation is set by CDI to the digest of the latest source image.
+	dataImportCronDesiredDigestAnnotation = "cdi.kubevirt.io/storage.import.sourceDesiredDigest"
+)
+
+func reportGoldenImageMetrics(dsAndCrons dataSourcesAndCrons, request *common.Request) error {
+	stalePeriod := defaultGoldenImageStalePeriod
+	notReadyPeriod := defaultGoldenImageNotReadyPeriod
+	if alerts := request.Instance.Spec.CommonTemplates.GoldenImageAlerts; alerts != nil {
+		if alerts.StalePeriod != nil {
+			stalePeriod = alerts.StalePeriod.Duration
+		}
+		if alerts.NotReadyPeriod != nil {
+			notReadyPeriod = alerts.NotReadyPeriod.Duration
+		}
+	}
+	metrics.SetGoldenImageAlertPeriods(stalePeriod, notReadyPeriod)
+
+	images, err := getGoldenImageStatuses(dsAndCrons, request)
+	if err != nil {
+		return err
+	}
+	metrics.SetGoldenImages(images)
+	return nil
+}
+
+func getGoldenImageStatuses(dsAndCrons dataSourcesAndCrons, request *common.Request) ([]metrics.GoldenImageStatus, error) {
+	cronByDataSource := make(map[client.ObjectKey]*cdiv1beta1.DataImportCron, len(dsAndCrons.dataImportCrons))
+	for _, cron := range dsAndCrons.dataImportCrons {
+		cronByDataSource[client.ObjectKey{
+			Name:      cron.Spec.ManagedDataSource,
+			Namespace: cron.Namespace,
+		}] = cron
+	}
+
+	var images []metrics.GoldenImageStatus
+	for i := range dsAndCrons.dataSourceInfos {
+		dsInfo := &dsAndCrons.dataSourceInfos[i]
+		dsKey := client.ObjectKeyFromObject(dsInfo.dataSource)
+
+		dataSource := &cdiv1beta1.DataSource{}
+		err := request.Client.Get(request.Context, dsKey, dataSource)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		image := metrics.GoldenImageStatus{
+			Name:      dataSource.Name,
+			Namespace: dataSource.Namespace,
+		}
+		if readyCondition := getDataSourceReadyCondition(dataSource); readyCondition != nil {
+			image.Ready = readyCondition.Status == core.ConditionTrue
+			image.ReadyTransitionTime = readyCondition.LastTransitionTime.Time
+		}
+
+		if cron, ok := cronByDataSource[dsKey]; ok && dsInfo.autoUpdateEnabled {
+			image.AutoUpdate = true
+			if err := setDataImportCronStatus(&image, cron, request); err != nil {
+				return nil, err
+			}
+		}
+
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+func setDataImportCronStatus(image *metrics.GoldenImageStatus, cron *cdiv1beta1.DataImportCron, request *common.Request) error {
+	foundCron := &cdiv1beta1.DataImportCron{}
+	err := request.Client.Get(request.Context, client.ObjectKeyFromObject(cron), foundCron)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if foundCron.Status.LastImportTimestamp != nil {
+		image.LastImportTime = foundCron.Status.LastImportTimestamp.Time
+	}
+
+	for _, condition := range foundCron.Status.Conditions {
+		if condition.Type != cdiv1beta1.DataImportCronUpToDate {
+			continue
+		}
+		if condition.Status == core.ConditionTrue {
+			// The desired digest is imported, so the image was up to date
+			// when the DataImportCron last polled the source.
+			image.Digest = foundCron.Annotations[dataImportCronDesiredDigestAnnotation]
+			if foundCron.Status.LastExecutionTimestamp != nil {
+				image.UpToDateTime = foundCron.Status.LastExecutionTimestamp.Time
+			}
+		} else {
+			// The image is outdated since the condition changed.
+			image.UpToDateTime = condition.LastTransitionTime.Time
+		}
+		break
+	}
+
+	if len(foundCron.Status.CurrentImports) == 0 {
+		return nil
+	}
+
+	// DataVolumes are not cached by the operator. Only DataVolumes
+	// of imports in progress are read, so the number of calls is small.
+	dataVolume := &cdiv1beta1.DataVolume{}
+	err = request.UncachedReader.Get(request.Context, client.ObjectKey{
+		Name:      foundCron.Status.CurrentImports[0].DataVolumeName,
+		Namespace: foundCron.Namespace,
+	}, dataVolume)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	image.ImportFailures = dataVolume.Status.RestartCount
+	return nil
+}
@@ -0,0 +1,343 @@
+package data_sources
+
+import (
+	"time"
+
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal/common"
+	metrics "kubevirt.io/ssp-operator/pkg/monitoring/metrics/ssp-operator"
+)
+
+const (
+	pvcKind            = "PersistentVolumeClaim"
+	volumeSnapshotKind = "VolumeSnapshot"
+
+	// cdiPodPhaseAnnotation is set by CDI on PVCs to the phase of the import or upload pod.
+	cdiPodPhaseAnnotation = "cdi.kubevirt.io/storage.pod.phase"
+
+	// orphanedGoldenImageMinAge is the minimum age of an orphaned volume before it is deleted,
+	// so volumes created by users before the DataSources that use them are not deleted.
+	orphanedGoldenImageMinAge = time.Hour
+
+	// orphanedGoldenImagesDeleteInterval limits how often DataVolumes and VMs in all namespaces
+	// are listed to find the clone sources, when orphaned volumes are deleted.
+	orphanedGoldenImagesDeleteInterval = 10 * time.Minute
+)
+
+var volumeSnapshotListGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshotList",
+}
+
+type volumeKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func volumeKeyFromObject(kind string, obj client.Object) volumeKey {
+	return volumeKey{
+		kind:      kind,
+		namespace: obj.GetNamespace(),
+		name:      obj.GetName(),
+	}
+}
+
+// reconcileOrphanedGoldenImages reports PVCs and VolumeSnapshots in the golden images namespace,
+// that are not used by any DataSource or DataImportCron. If the policy is Delete, orphaned volumes
+// that are older than the minimum age and are not a clone source of any DataVolume or VM are deleted.
+// The deletion runs at most once per orphanedGoldenImagesDeleteInterval.
+func (d *dataSources) reconcileOrphanedGoldenImages(request *common.Request) error {
+	orphans, err := getOrphanedGoldenImages(request)
+	if err != nil {
+		return err
+	}
+
+	if request.Instance.Spec.CommonTemplates.OrphanedGoldenImages == ssp.OrphanedGoldenImagesDelete &&
+		time.Since(d.lastOrphanDeletion) >= orphanedGoldenImagesDeleteInterval {
+		var deleted bool
+		orphans, deleted, err = deleteOrphanedGoldenImages(orphans, request)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			d.lastOrphanDeletion = time.Now()
+		}
+	}
+
+	reported := make([]metrics.OrphanedGoldenImage, 0, len(orphans))
+	for key := range orphans {
+		reported = append(reported, metrics.OrphanedGoldenImage{
+			Name:      key.name,
+			Namespace: key.namespace,
+			Kind:      key.kind,
+		})
+	}
+	metrics.SetOrphanedGoldenImages(reported)
+	return nil
+}
+
+func getOrphanedGoldenImages(request *common.Request) (map[volumeKey]client.Object, error) {
+	namespace := common.GetGoldenImagesNamespace(&request.Instance.Spec)
+
+	usedVolumes, cronNames, err := getUsedGoldenImageVolumes(namespace, request)
+	if err != nil {
+		return nil, err
+	}
+
+	isOrphaned := func(key volumeKey, obj client.Object) bool {
+		if usedVolumes[key] || !obj.GetDeletionTimestamp().IsZero() {
+			return false
+		}
+		// Volumes owned by another object, like the target PVC of a DataVolume, are managed by their owner.
+		if len(obj.GetOwnerReferences()) > 0 {
+			return false
+		}
+		// Old imports of existing DataImportCrons are garbage collected by CDI.
+		if cronName, ok := obj.GetLabels()[dataImportCronLabel]; ok && cronNames[cronName] {
+			return false
+		}
+		return true
+	}
+
+	orphans := map[volumeKey]client.Object{}
+
+	// PVCs and VolumeSnapshots are not cached by the operator.
+	pvcs := &core.PersistentVolumeClaimList{}
+	if err := request.UncachedReader.List(request.Context, pvcs, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+		if phase, ok := pvc.Annotations[cdiPodPhaseAnnotation]; ok && phase != string(core.PodSucceeded) {
+			// The PVC is being imported or uploaded.
+			continue
+		}
+		key := volumeKeyFromObject(pvcKind, pvc)
+		if isOrphaned(key, pvc) {
+			orphans[key] = pvc
+		}
+	}
+
+	if len(orphans) > 0 {
+		mountedPvcs, err := getMountedPvcs(namespace, request)
+		if err != nil {
+			return nil, err
+		}
+		for key := range orphans {
+			if mountedPvcs[key] {
+				delete(orphans, key)
+			}
+		}
+	}
+
+	snapshots := &unstructured.UnstructuredList{}
+	snapshots.SetGroupVersionKind(volumeSnapshotListGVK)
+	err = request.UncachedReader.List(request.Context, snapshots, client.InNamespace(namespace))
+	if meta.IsNoMatchError(err) {
+		// VolumeSnapshots are not supported on the cluster.
+		return orphans, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range snapshots.Items {
+		snapshot := &snapshots.Items[i]
+		key := volumeKeyFromObject(volumeSnapshotKind, snapshot)
+		if isOrphaned(key, snapshot) {
+			orphans[key] = snapshot
+		}
+	}
+
+	return orphans, nil
+}
+
+// getUsedGoldenImageVolumes returns the volumes in the namespace referenced by DataSources
+// and DataImportCrons, and the names of DataImportCrons in the namespace.
+func getUsedGoldenImageVolumes(namespace string, request *common.Request) (map[volumeKey]bool, map[string]bool, error) {
+	usedVolumes := map[volumeKey]bool{}
+	addVolume := func(kind, volumeNamespace, volumeName, defaultNamespace string) {
+		volumeNamespace = namespaceOrDefault(volumeNamespace, defaultNamespace)
+		if volumeNamespace == namespace {
+			usedVolumes[volumeKey{kind: kind, namespace: volumeNamespace, name: volumeName}] = true
+		}
+	}
+
+	// DataSources in all namespaces are listed, because they can refer to volumes in another namespace.
+	dataSources := &cdiv1beta1.DataSourceList{}
+	if err := request.Client.List(request.Context, dataSources); err != nil {
+		return nil, nil, err
+	}
+	for i := range dataSources.Items {
+		dataSource := &dataSources.Items[i]
+		if pvc := dataSource.Spec.Source.PVC; pvc != nil {
+			addVolume(pvcKind, pvc.Namespace, pvc.Name, dataSource.Namespace)
+		}
+		if snapshot := dataSource.Spec.Source.Snapshot; snapshot != nil {
+			addVolume(volumeSnapshotKind, snapshot.Namespace, snapshot.Name, dataSource.Namespace)
+		}
+	}
+
+	crons := &cdiv1beta1.DataImportCronList{}
+	if err := request.Client.List(request.Context, crons, client.InNamespace(namespace)); err != nil {
+		return nil, nil, err
+	}
+	cronNames := make(map[string]bool, len(crons.Items))
+	for i := range crons.Items {
+		cron := &crons.Items[i]
+		cronNames[cron.Name] = true
+
+		// Imported volumes have the same name, regardless of the source format.
+		if lastImported := cron.Status.LastImportedPVC; lastImported != nil {
+			addVolume(pvcKind, lastImported.Namespace, lastImported.Name, cron.Namespace)
+			addVolume(volumeSnapshotKind, lastImported.Namespace, lastImported.Name, cron.Namespace)
+		}
+		for _, currentImport := range cron.Status.CurrentImports {
+			addVolume(pvcKind, cron.Namespace, currentImport.DataVolumeName, cron.Namespace)
+			addVolume(volumeSnapshotKind, cron.Namespace, currentImport.DataVolumeName, cron.Namespace)
+		}
+	}
+
+	return usedVolumes, cronNames, nil
+}
+
+// getMountedPvcs returns the PVCs in the namespace that are used as a volume by a pod or a VM in the namespace.
+func getMountedPvcs(namespace string, request *common.Request) (map[volumeKey]bool, error) {
+	mountedPvcs := map[volumeKey]bool{}
+	addPvc := func(name string) {
+		mountedPvcs[volumeKey{kind: pvcKind, namespace: namespace, name: name}] = true
+	}
+
+	// Pods and VMs are not cached by the operator. They are listed only
+	// in the golden images namespace, and only if there are orphaned PVCs.
+	pods := &core.PodList{}
+	if err := request.UncachedReader.List(request.Context, pods, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		for _, volume := range pods.Items[i].Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				addPvc(volume.PersistentVolumeClaim.ClaimName)
+			}
+		}
+	}
+
+	vms := &kubevirtv1.VirtualMachineList{}
+	if err := request.UncachedReader.List(request.Context, vms, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for i := range vms.Items {
+		vmTemplate := vms.Items[i].Spec.Template
+		if vmTemplate == nil {
+			continue
+		}
+		for _, volume := range vmTemplate.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				addPvc(volume.PersistentVolumeClaim.ClaimName)
+			}
+			// The PVC of a DataVolume has the same name.
+			if volume.DataVolume != nil {
+				addPvc(volume.DataVolume.Name)
+			}
+		}
+	}
+
+	return mountedPvcs, nil
+}
+
+// deleteOrphanedGoldenImages deletes the orphaned volumes that are older than the minimum age
+// and are not a clone source of any DataVolume or VM, and returns the orphaned volumes that were
+// not deleted. It returns true if the clone sources were listed.
+func deleteOrphanedGoldenImages(orphans map[volumeKey]client.Object, request *common.Request) (map[volumeKey]client.Object, bool, error) {
+	remaining := map[volumeKey]client.Object{}
+	candidates := map[volumeKey]client.Object{}
+	for key, obj := range orphans {
+		if time.Since(obj.GetCreationTimestamp().Time) < orphanedGoldenImageMinAge {
+			remaining[key] = obj
+		} else {
+			candidates[key] = obj
+		}
+	}
+	if len(candidates) == 0 {
+		return remaining, false, nil
+	}
+
+	cloneSources, err := getCloneSourceVolumes(request)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for key, obj := range candidates {
+		if cloneSources[key] {
+			remaining[key] = obj
+			continue
+		}
+
+		err := request.Client.Delete(request.Context, obj, client.Preconditions{
+			UID:             ptr.To(obj.GetUID()),
+			ResourceVersion: ptr.To(obj.GetResourceVersion()),
+		})
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, false, err
+		}
+		request.Logger.Info("Deleted orphaned golden image", "kind", key.kind, "namespace", key.namespace, "name", key.name)
+	}
+	return remaining, true, nil
+}
+
+// getCloneSourceVolumes returns volumes used as a clone source by DataVolumes or VM DataVolume templates in any namespace.
+func getCloneSourceVolumes(request *common.Request) (map[volumeKey]bool, error) {
+	cloneSources := map[volumeKey]bool{}
+	addSource := func(source *cdiv1beta1.DataVolumeSource, defaultNamespace string) {
+		if source == nil {
+			return
+		}
+		if pvc := source.PVC; pvc != nil {
+			cloneSources[volumeKey{kind: pvcKind, namespace: namespaceOrDefault(pvc.Namespace, defaultNamespace), name: pvc.Name}] = true
+		}
+		if snapshot := source.Snapshot; snapshot != nil {
+			cloneSources[volumeKey{kind: volumeSnapshotKind, namespace: namespaceOrDefault(snapshot.Namespace, defaultNamespace), name: snapshot.Name}] = true
+		}
+	}
+
+	// DataVolumes and VMs are not cached by the operator. They are listed only when orphaned volumes
+	// are deleted, at most once per orphanedGoldenImagesDeleteInterval.
+	dataVolumes := &cdiv1beta1.DataVolumeList{}
+	if err := request.UncachedReader.List(request.Context, dataVolumes); err != nil {
+		return nil, err
+	}
+	for i := range dataVolumes.Items {
+		addSource(dataVolumes.Items[i].Spec.Source, dataVolumes.Items[i].Namespace)
+	}
+
+	vms := &kubevirtv1.VirtualMachineList{}
+	if err := request.UncachedReader.List(request.Context, vms); err != nil {
+		return nil, err
+	}
+	for i := range vms.Items {
+		vm := &vms.Items[i]
+		for j := range vm.Spec.DataVolumeTemplates {
+			addSource(vm.Spec.DataVolumeTemplates[j].Spec.Source, vm.Namespace)
+		}
+	}
+
+	return cloneSources, nil
+}
+
+func namespaceOrDefault(namespace, defaultNamespace string) string {
+	if namespace == "" {
+		return defaultNamespace
+	}
+	return namespace
+}
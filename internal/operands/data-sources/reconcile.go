@@ -2,9 +2,13 @@ package data_sources
 
 import (
 	"fmt"
+	"hash/fnv"
 	"maps"
+	"path"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	core "k8s.io/api/core/v1"
@@ -17,12 +21,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	ssp "kubevirt.io/ssp-operator/api/v1beta3"
-	"kubevirt.io/ssp-operator/internal"
 	"kubevirt.io/ssp-operator/internal/architecture"
 	"kubevirt.io/ssp-operator/internal/common"
 	"kubevirt.io/ssp-operator/internal/operands"
 	common_templates "kubevirt.io/ssp-operator/internal/operands/common-templates"
 	template_bundle "kubevirt.io/ssp-operator/internal/template-bundle"
+	metrics "kubevirt.io/ssp-operator/pkg/monitoring/metrics/ssp-operator"
 )
 
 // Define RBAC rules needed by this operand:
@@ -31,6 +35,9 @@ import (
 // +kubebuilder:rbac:groups=cdi.kubevirt.io,resources=datasources,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cdi.kubevirt.io,resources=dataimportcrons,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=list;watch;create;update;delete
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=delete
+// +kubebuilder:rbac:groups=kubevirt.io,resources=virtualmachines,verbs=list
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=list
 
 // RBAC for created roles
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
@@ -72,6 +79,9 @@ func WatchClusterTypes() []operands.WatchType {
 type dataSources struct {
 	sourceCollection   template_bundle.DataSourceCollection
 	runningOnOpenShift bool
+
+	// lastOrphanDeletion is the last time the clone sources of orphaned golden images were listed.
+	lastOrphanDeletion time.Time
 }
 
 var _ operands.Operand = &dataSources{}
@@ -124,7 +134,13 @@ func (d *dataSources) Reconcile(request *common.Request) ([]common.ReconcileResu
 		return nil, err
 	}
 	funcs = append(funcs, dsFuncs...)
-	funcs = append(funcs, d.reconcileNetworkPolicies()...)
+	funcs = append(funcs, d.reconcileNetworkPolicies(common.GetGoldenImagesNamespace(&request.Instance.Spec))...)
+
+	previousNamespaceFuncs, err := reconcilePreviousNamespaceResources(request)
+	if err != nil {
+		return nil, err
+	}
+	funcs = append(funcs, previousNamespaceFuncs...)
 
 	dicFuncs, err := reconcileDataImportCrons(dsAndCrons.dataImportCrons, request)
 	if err != nil {
@@ -132,10 +148,24 @@ func (d *dataSources) Reconcile(request *common.Request) ([]common.ReconcileResu
 	}
 	funcs = append(funcs, dicFuncs...)
 
-	return common.CollectResourceStatus(request, funcs...)
+	results, err := common.CollectResourceStatus(request, funcs...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reportGoldenImageMetrics(dsAndCrons, request); err != nil {
+		request.Logger.Error(err, "Failed to report golden image metrics")
+	}
+	if err := d.reconcileOrphanedGoldenImages(request); err != nil {
+		request.Logger.Error(err, "Failed to reconcile orphaned golden images")
+	}
+	return results, nil
 }
 
 func (d *dataSources) Cleanup(request *common.Request) ([]common.CleanupResult, error) {
+	metrics.SetGoldenImages(nil)
+	metrics.SetOrphanedGoldenImages(nil)
+
 	if request.CrdList.CrdExists(dataImportCronCrd) {
 		ownedCrons, err := listAllOwnedDataImportCrons(request)
 		if err != nil {
@@ -173,35 +203,45 @@ func (d *dataSources) Cleanup(request *common.Request) ([]common.CleanupResult,
 		}
 	}
 
+	goldenImagesNamespace := common.GetGoldenImagesNamespace(&request.Instance.Spec)
 	objects = append(objects,
-		newGoldenImagesNS(internal.GoldenImagesNamespace),
-		newViewRole(internal.GoldenImagesNamespace),
-		newViewRoleBinding(internal.GoldenImagesNamespace),
+		newGoldenImagesNS(goldenImagesNamespace),
+		newViewRole(goldenImagesNamespace),
+		newViewRoleBinding(goldenImagesNamespace, nil),
 		newEditRole())
-	for _, policy := range newNetworkPolicies(internal.GoldenImagesNamespace, d.runningOnOpenShift) {
+	for _, policy := range newNetworkPolicies(goldenImagesNamespace, d.runningOnOpenShift) {
 		objects = append(objects, policy)
 	}
 
+	previousResources, err := listPreviousNamespaceResources(request)
+	if err != nil {
+		return nil, err
+	}
+	objects = append(objects, previousResources...)
+
 	return common.DeleteAll(request, objects...)
 }
 
 func reconcileGoldenImagesNS(request *common.Request) (common.ReconcileResult, error) {
 	return common.CreateOrUpdate(request).
-		ClusterResource(newGoldenImagesNS(internal.GoldenImagesNamespace)).
+		ClusterResource(newGoldenImagesNS(common.GetGoldenImagesNamespace(&request.Instance.Spec))).
 		WithAppLabels(operandName, operandComponent).
 		Reconcile()
 }
 
 func reconcileViewRole(request *common.Request) (common.ReconcileResult, error) {
 	return common.CreateOrUpdate(request).
-		ClusterResource(newViewRole(internal.GoldenImagesNamespace)).
+		ClusterResource(newViewRole(common.GetGoldenImagesNamespace(&request.Instance.Spec))).
 		WithAppLabels(operandName, operandComponent).
 		Reconcile()
 }
 
 func reconcileViewRoleBinding(request *common.Request) (common.ReconcileResult, error) {
 	return common.CreateOrUpdate(request).
-		ClusterResource(newViewRoleBinding(internal.GoldenImagesNamespace)).
+		ClusterResource(newViewRoleBinding(
+			common.GetGoldenImagesNamespace(&request.Instance.Spec),
+			request.Instance.Spec.CommonTemplates.GoldenImageAccess,
+		)).
 		WithAppLabels(operandName, operandComponent).
 		Reconcile()
 }
@@ -227,10 +267,15 @@ func (d *dataSources) getDataSourcesAndCrons(request *common.Request) (dataSourc
 		cronByDataSource = getCronsByDataSource(&request.Instance.Spec)
 	}
 
+	previousDataSources, err := getPreviousDataSources(request)
+	if err != nil {
+		return dataSourcesAndCrons{}, fmt.Errorf("failed to list DataSources in previous namespaces: %w", err)
+	}
+
 	var dataSourceInfos []dataSourceInfo
 	if isMultiarch {
 		var err error
-		dataSourceInfos, err = getDataSourceInfosMultiArch(d.sourceCollection, cronByDataSource, request)
+		dataSourceInfos, err = getDataSourceInfosMultiArch(d.sourceCollection, cronByDataSource, previousDataSources, request)
 		if err != nil {
 			return dataSourcesAndCrons{}, fmt.Errorf("failed to get DataSources: %w", err)
 		}
@@ -240,10 +285,11 @@ func (d *dataSources) getDataSourcesAndCrons(request *common.Request) (dataSourc
 			return dataSourcesAndCrons{}, fmt.Errorf("failed to get ClusterArchs: %w", err)
 		}
 
-		dataSourceInfos = addDataSourceReferenceForCrons(dataSourceInfos, request.Instance.Spec.CommonTemplates.DataImportCronTemplates, clusterArchs)
+		dataSourceInfos = addDataSourceReferenceForCrons(dataSourceInfos, &request.Instance.Spec.CommonTemplates, clusterArchs,
+			common.GetGoldenImagesNamespace(&request.Instance.Spec))
 	} else {
 		var err error
-		dataSourceInfos, err = getDataSourceInfos(d.sourceCollection, cronByDataSource, request)
+		dataSourceInfos, err = getDataSourceInfos(d.sourceCollection, cronByDataSource, previousDataSources, request)
 		if err != nil {
 			return dataSourcesAndCrons{}, fmt.Errorf("failed to get DataSources: %w", err)
 		}
@@ -255,6 +301,9 @@ func (d *dataSources) getDataSourcesAndCrons(request *common.Request) (dataSourc
 		}
 	}
 
+	dataSourceInfos = addDataSourceAliases(dataSourceInfos, cronByDataSource, request.Instance.Spec.CommonTemplates.DataSourceAliases,
+		common.GetGoldenImagesNamespace(&request.Instance.Spec), &request.Logger)
+
 	return dataSourcesAndCrons{
 		dataSourceInfos: dataSourceInfos,
 		dataImportCrons: slices.Collect(maps.Values(cronByDataSource)),
@@ -262,14 +311,23 @@ func (d *dataSources) getDataSourcesAndCrons(request *common.Request) (dataSourc
 }
 
 func getCronsByDataSource(sspSpec *ssp.SSPSpec) map[client.ObjectKey]*cdiv1beta1.DataImportCron {
+	goldenImagesNamespace := common.GetGoldenImagesNamespace(sspSpec)
 	cronTemplates := sspSpec.CommonTemplates.DataImportCronTemplates
 	cronByDataSource := make(map[client.ObjectKey]*cdiv1beta1.DataImportCron, len(cronTemplates))
 	for i := range cronTemplates {
+		if isDataImportCronDisabled(sspSpec.CommonTemplates.DisabledDataImportCrons, cronTemplates[i].Name, "") {
+			continue
+		}
+
 		originalCron := cronTemplates[i].AsDataImportCron()
 		cron := originalCron.DeepCopy()
 		if cron.Namespace == "" {
-			cron.Namespace = internal.GoldenImagesNamespace
+			cron.Namespace = goldenImagesNamespace
 		}
+		applyStoragePolicy(cron, sspSpec.CommonTemplates.GoldenImageStoragePolicy)
+		applyRegistryMirrors(cron, sspSpec.CommonTemplates.GoldenImageRegistryMirrors)
+		applyImportWindow(cron, sspSpec.CommonTemplates.GoldenImageImportWindow)
+
 		// The architecture annotation should not be in the created DataImportCron.
 		delete(cron.Annotations, DataImportCronArchsAnnotation)
 
@@ -289,34 +347,48 @@ func getCronsByDataSourceMultiArch(sspSpec *ssp.SSPSpec, sourceCollection templa
 		return nil, err
 	}
 
+	goldenImagesNamespace := common.GetGoldenImagesNamespace(sspSpec)
 	cronByDataSource := map[client.ObjectKey]*cdiv1beta1.DataImportCron{}
 	cronTemplates := sspSpec.CommonTemplates.DataImportCronTemplates
+	disabledCrons := sspSpec.CommonTemplates.DisabledDataImportCrons
 	for i := range cronTemplates {
 		originalCron := cronTemplates[i].AsDataImportCron()
 
 		// Need a copy, because it is modified later.
 		cron := originalCron.DeepCopy()
 		if cron.Namespace == "" {
-			cron.Namespace = internal.GoldenImagesNamespace
+			cron.Namespace = goldenImagesNamespace
 		}
+		// The policy is applied before the architecture suffix is added, so size overrides
+		// match the same DataSource name on all architectures.
+		applyStoragePolicy(cron, sspSpec.CommonTemplates.GoldenImageStoragePolicy)
+		applyRegistryMirrors(cron, sspSpec.CommonTemplates.GoldenImageRegistryMirrors)
 
 		archsAnnotationValue := cron.Annotations[DataImportCronArchsAnnotation]
 
 		// The architecture annotation should not be in the created DataImportCron.
 		delete(cron.Annotations, DataImportCronArchsAnnotation)
 
+		dsClusterArchs := getDataSourceClusterArchs(cron.Spec.ManagedDataSource, clusterArchs, sspSpec.CommonTemplates.DataSourceArchitectureOverrides)
 		if archsAnnotationValue == "" {
 			// The ManagedDataSource needs to point to the default DataSource architecture.
 			dsArchs, dsExists := sourceCollection[cron.Spec.ManagedDataSource]
 			if !dsExists {
-				addToCronMap(cronByDataSource, cron)
+				if !isDataImportCronDisabled(disabledCrons, cron.Name, "") {
+					addToCronMap(cronByDataSource, cron)
+				}
 				continue
 			}
 
-			defaultArch := getDefaultDataSourceArch(clusterArchs, dsArchs)
+			defaultArch := getDefaultDataSourceArch(dsClusterArchs, dsArchs)
 			if defaultArch == "" {
 				// If there is no compatible DataSource architecture, no DataSource is created.
-				addToCronMap(cronByDataSource, cron)
+				if !isDataImportCronDisabled(disabledCrons, cron.Name, "") {
+					addToCronMap(cronByDataSource, cron)
+				}
+				continue
+			}
+			if isDataImportCronDisabled(disabledCrons, cron.Name, defaultArch) {
 				continue
 			}
 
@@ -327,7 +399,7 @@ func getCronsByDataSourceMultiArch(sspSpec *ssp.SSPSpec, sourceCollection templa
 
 		cronArchs := parseArchsAnnotation(archsAnnotationValue, logger)
 		for _, arch := range cronArchs {
-			if !slices.Contains(clusterArchs, arch) {
+			if !slices.Contains(dsClusterArchs, arch) || isDataImportCronDisabled(disabledCrons, cron.Name, arch) {
 				continue
 			}
 
@@ -338,9 +410,134 @@ func getCronsByDataSourceMultiArch(sspSpec *ssp.SSPSpec, sourceCollection templa
 		}
 	}
 
+	// The import time is computed from the final name of the managed DataSource,
+	// so DataImportCrons of different architectures are spread across the window.
+	for _, cron := range cronByDataSource {
+		applyImportWindow(cron, sspSpec.CommonTemplates.GoldenImageImportWindow)
+	}
+
 	return cronByDataSource, nil
 }
 
+// isDataImportCronDisabled returns true if the DataImportCron created from the template with the name
+// is disabled for the architecture. If the architecture is empty, the architecture of disabled entries is ignored.
+func isDataImportCronDisabled(disabledCrons []ssp.DisabledDataImportCron, name string, arch architecture.Arch) bool {
+	return slices.ContainsFunc(disabledCrons, func(disabled ssp.DisabledDataImportCron) bool {
+		return disabled.Name == name && (arch == "" || disabled.Architecture == "" || disabled.Architecture == string(arch))
+	})
+}
+
+// applyStoragePolicy sets the storage settings from the golden image storage policy
+// to the DataImportCron, if the DataImportCron template does not define them.
+func applyStoragePolicy(cron *cdiv1beta1.DataImportCron, policy *ssp.GoldenImageStoragePolicy) {
+	if policy == nil || cron.Spec.Template.Spec.PVC != nil {
+		// Templates using the PVC API define all storage settings explicitly.
+		return
+	}
+
+	if cron.Spec.Template.Spec.Storage == nil {
+		cron.Spec.Template.Spec.Storage = &cdiv1beta1.StorageSpec{}
+	}
+	storage := cron.Spec.Template.Spec.Storage
+
+	if storage.StorageClassName == nil && policy.StorageClassName != nil {
+		storage.StorageClassName = ptr.To(*policy.StorageClassName)
+	}
+	if len(storage.AccessModes) == 0 && len(policy.AccessModes) > 0 {
+		storage.AccessModes = slices.Clone(policy.AccessModes)
+	}
+	if storage.VolumeMode == nil && policy.VolumeMode != nil {
+		storage.VolumeMode = ptr.To(*policy.VolumeMode)
+	}
+
+	if _, sizeDefined := storage.Resources.Requests[core.ResourceStorage]; sizeDefined {
+		return
+	}
+	for _, override := range policy.SizeOverrides {
+		if matched, _ := path.Match(override.DataSourcePattern, cron.Spec.ManagedDataSource); !matched {
+			continue
+		}
+		if storage.Resources.Requests == nil {
+			storage.Resources.Requests = core.ResourceList{}
+		}
+		storage.Resources.Requests[core.ResourceStorage] = override.Size.DeepCopy()
+		return
+	}
+}
+
+// applyRegistryMirrors replaces the registry URL of the DataImportCron source
+// with the first mirror whose source prefix matches it.
+func applyRegistryMirrors(cron *cdiv1beta1.DataImportCron, mirrors []ssp.GoldenImageRegistryMirror) {
+	source := cron.Spec.Template.Spec.Source
+	if source == nil || source.Registry == nil || source.Registry.URL == nil {
+		return
+	}
+	registry := source.Registry
+
+	for _, mirror := range mirrors {
+		url, ok := rewriteRegistryURL(*registry.URL, mirror.Source, mirror.Mirror)
+		if !ok {
+			continue
+		}
+		registry.URL = ptr.To(url)
+		if mirror.SecretRef != "" {
+			registry.SecretRef = ptr.To(mirror.SecretRef)
+		}
+		if mirror.CertConfigMap != "" {
+			registry.CertConfigMap = ptr.To(mirror.CertConfigMap)
+		}
+		return
+	}
+}
+
+// rewriteRegistryURL replaces the prefix of the image in a registry URL, like "docker://quay.io/containerdisks/fedora:latest".
+// The prefix matches only whole path components, so "quay.io/containerdisks" does not match "quay.io/containerdisks-dev/fedora".
+func rewriteRegistryURL(url, sourcePrefix, mirrorPrefix string) (string, bool) {
+	transport, image, found := strings.Cut(url, "://")
+	if !found {
+		return "", false
+	}
+
+	sourcePrefix = strings.TrimSuffix(sourcePrefix, "/")
+	rest, found := strings.CutPrefix(image, sourcePrefix)
+	if !found || (rest != "" && !strings.ContainsAny(rest[:1], "/:@")) {
+		return "", false
+	}
+	return transport + "://" + strings.TrimSuffix(mirrorPrefix, "/") + rest, true
+}
+
+// applyImportWindow replaces the minute and the hour of the DataImportCron schedule with a time in the import window.
+// The time is computed from the name of the managed DataSource, so imports of different DataSources
+// are spread across the window, and the schedule of a DataSource does not change between reconciliations.
+//
+// Only schedules with five fields, that run at a single minute and hour of the day, are changed.
+// Other schedules are kept, so the polling frequency of schedules like "0 */12 * * *" is preserved.
+func applyImportWindow(cron *cdiv1beta1.DataImportCron, window *ssp.GoldenImageImportWindow) {
+	if window == nil || window.Hours <= 0 {
+		return
+	}
+
+	fields := strings.Fields(cron.Spec.Schedule)
+	if len(fields) != 5 || !isCronNumber(fields[0]) || !isCronNumber(fields[1]) {
+		return
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(cron.Spec.ManagedDataSource))
+	offsetMinutes := hash.Sum32() % uint32(window.Hours*60)
+	minute := offsetMinutes % 60
+	hour := (uint32(window.StartHour) + offsetMinutes/60) % 24
+
+	// The day of month, month and day of week are kept from the template schedule.
+	cron.Spec.Schedule = fmt.Sprintf("%d %d %s", minute, hour, strings.Join(fields[2:], " "))
+}
+
+// isCronNumber returns true if the cron schedule field matches a single value.
+func isCronNumber(field string) bool {
+	_, err := strconv.ParseUint(field, 10, 32)
+	return err == nil
+}
+
 func addToCronMap(cronMap map[client.ObjectKey]*cdiv1beta1.DataImportCron, cron *cdiv1beta1.DataImportCron) {
 	cronMap[client.ObjectKey{
 		Name:      cron.Spec.ManagedDataSource,
@@ -350,7 +547,8 @@ func addToCronMap(cronMap map[client.ObjectKey]*cdiv1beta1.DataImportCron, cron
 
 // addDataSourceReferenceForCrons adds DataSource references for custom DataImportCron templates.
 // The SSP object can contain DataImportCron templates that don't have a common template defined.
-func addDataSourceReferenceForCrons(dataSourceInfos []dataSourceInfo, cronTemplates []ssp.DataImportCronTemplate, clusterArchs []architecture.Arch) []dataSourceInfo {
+func addDataSourceReferenceForCrons(dataSourceInfos []dataSourceInfo, commonTemplates *ssp.CommonTemplates, clusterArchs []architecture.Arch, namespace string) []dataSourceInfo {
+	cronTemplates := commonTemplates.DataImportCronTemplates
 	for i := range cronTemplates {
 		originalCron := cronTemplates[i].AsDataImportCron()
 		cron := originalCron.DeepCopy()
@@ -369,15 +567,61 @@ func addDataSourceReferenceForCrons(dataSourceInfos []dataSourceInfo, cronTempla
 		}
 
 		cronArchs := parseArchsAnnotation(archsAnnotationValue, nil)
-		defaultArch := getDefaultDataSourceArch(clusterArchs, cronArchs)
+		dsClusterArchs := getDataSourceClusterArchs(dsName, clusterArchs, commonTemplates.DataSourceArchitectureOverrides)
+		defaultArch := getDefaultDataSourceArch(dsClusterArchs, cronArchs)
 		if defaultArch == "" {
 			// There is no compatible architecture of DataImportCron. It will not be created.
 			continue
 		}
+		if isDataImportCronDisabled(commonTemplates.DisabledDataImportCrons, cron.Name, defaultArch) {
+			// The referenced DataSource is managed by the DataImportCron, which will not be created.
+			continue
+		}
+
+		dataSourceInfos = append(dataSourceInfos, dataSourceInfo{
+			dataSource: newDataSourceReference(dsName, dsName+"-"+string(defaultArch), namespace),
+		})
+	}
+	return dataSourceInfos
+}
+
+// addDataSourceAliases adds DataSources referring to the DataSources managed by the operator,
+// or by the DataImportCrons managed by the operator.
+func addDataSourceAliases(dataSourceInfos []dataSourceInfo, cronByDataSource map[client.ObjectKey]*cdiv1beta1.DataImportCron, aliases []ssp.DataSourceAlias, namespace string, logger *logr.Logger) []dataSourceInfo {
+	dataSourceByName := make(map[string]*cdiv1beta1.DataSource, len(dataSourceInfos))
+	for i := range dataSourceInfos {
+		dataSourceByName[dataSourceInfos[i].dataSource.Name] = dataSourceInfos[i].dataSource
+	}
+
+	for _, alias := range aliases {
+		if _, exists := dataSourceByName[alias.Name]; exists {
+			logger.Info("DataSource alias has the same name as another DataSource, ignoring it.", "alias", alias.Name)
+			continue
+		}
+		// DataSources managed by custom DataImportCrons are not in the dataSourceInfos.
+		if _, exists := cronByDataSource[client.ObjectKey{Name: alias.Name, Namespace: namespace}]; exists {
+			logger.Info("DataSource alias has the same name as a DataSource managed by a DataImportCron, ignoring it.", "alias", alias.Name)
+			continue
+		}
+
+		referenceName := alias.DataSource
+		if target, ok := dataSourceByName[alias.DataSource]; ok {
+			// CDI resolves only one level of DataSource references,
+			// so the alias refers directly to the DataSource referenced by the target.
+			if target.Spec.Source.DataSource != nil {
+				referenceName = target.Spec.Source.DataSource.Name
+			}
+		} else if _, ok := cronByDataSource[client.ObjectKey{Name: alias.DataSource, Namespace: namespace}]; !ok {
+			logger.Info("DataSource alias refers to a DataSource that is not managed, ignoring it.",
+				"alias", alias.Name, "dataSource", alias.DataSource)
+			continue
+		}
 
+		dataSource := newDataSourceReference(alias.Name, referenceName, namespace)
 		dataSourceInfos = append(dataSourceInfos, dataSourceInfo{
-			dataSource: newDataSourceReference(dsName, dsName+"-"+string(defaultArch)),
+			dataSource: dataSource,
 		})
+		dataSourceByName[alias.Name] = dataSource
 	}
 	return dataSourceInfos
 }
@@ -406,7 +650,7 @@ func setDataImportCronArchFields(cron *cdiv1beta1.DataImportCron, arch architect
 	}
 }
 
-func getDataSourceInfos(sourceCollection template_bundle.DataSourceCollection, cronByDataSource map[client.ObjectKey]*cdiv1beta1.DataImportCron, request *common.Request) ([]dataSourceInfo, error) {
+func getDataSourceInfos(sourceCollection template_bundle.DataSourceCollection, cronByDataSource map[client.ObjectKey]*cdiv1beta1.DataImportCron, previousDataSources map[string]*cdiv1beta1.DataSource, request *common.Request) ([]dataSourceInfo, error) {
 	if ptr.Deref(request.Instance.Spec.EnableMultipleArchitectures, false) {
 		return nil, fmt.Errorf(".spec.enableMultipleArchitectures needs to be false")
 	}
@@ -415,18 +659,26 @@ func getDataSourceInfos(sourceCollection template_bundle.DataSourceCollection, c
 	if err != nil {
 		return nil, err
 	}
+
+	goldenImagesNamespace := common.GetGoldenImagesNamespace(&request.Instance.Spec)
 	var dataSourceInfos []dataSourceInfo
 	for name := range sourceCollection.Names() {
 		if !sourceCollection.Contains(name, clusterArchs[0]) {
 			continue
 		}
 
-		dataSource := newDataSource(name)
+		dataSource := newDataSource(name, goldenImagesNamespace)
 		autoUpdateEnabled, err := dataSourceAutoUpdateEnabled(dataSource, cronByDataSource, request)
 		if err != nil {
 			return nil, err
 		}
 
+		if !autoUpdateEnabled {
+			if err := keepMigratedDataSourceSource(dataSource, previousDataSources, request); err != nil {
+				return nil, err
+			}
+		}
+
 		var dicName string
 		if dic, ok := cronByDataSource[client.ObjectKeyFromObject(dataSource)]; ok {
 			dicName = dic.GetName()
@@ -441,7 +693,7 @@ func getDataSourceInfos(sourceCollection template_bundle.DataSourceCollection, c
 	return dataSourceInfos, nil
 }
 
-func getDataSourceInfosMultiArch(sourceCollection template_bundle.DataSourceCollection, cronByDataSource map[client.ObjectKey]*cdiv1beta1.DataImportCron, request *common.Request) ([]dataSourceInfo, error) {
+func getDataSourceInfosMultiArch(sourceCollection template_bundle.DataSourceCollection, cronByDataSource map[client.ObjectKey]*cdiv1beta1.DataImportCron, previousDataSources map[string]*cdiv1beta1.DataSource, request *common.Request) ([]dataSourceInfo, error) {
 	if !ptr.Deref(request.Instance.Spec.EnableMultipleArchitectures, false) {
 		return nil, fmt.Errorf("multi-architecture needs to be enabled")
 	}
@@ -451,9 +703,12 @@ func getDataSourceInfosMultiArch(sourceCollection template_bundle.DataSourceColl
 		return nil, err
 	}
 
+	goldenImagesNamespace := common.GetGoldenImagesNamespace(&request.Instance.Spec)
 	var dataSourceInfos []dataSourceInfo
+	archOverrides := request.Instance.Spec.CommonTemplates.DataSourceArchitectureOverrides
 	for name, dsArchs := range sourceCollection {
-		defaultArch := getDefaultDataSourceArch(clusterArchs, dsArchs)
+		dsClusterArchs := getDataSourceClusterArchs(name, clusterArchs, archOverrides)
+		defaultArch := getDefaultDataSourceArch(dsClusterArchs, dsArchs)
 		if defaultArch == "" {
 			// We can skip creating the DataSources, because none of its architectures
 			// are supported on the cluster.
@@ -461,16 +716,16 @@ func getDataSourceInfosMultiArch(sourceCollection template_bundle.DataSourceColl
 		}
 
 		dataSourceInfos = append(dataSourceInfos, dataSourceInfo{
-			dataSource: newDataSourceReference(name, name+"-"+string(defaultArch)),
+			dataSource: newDataSourceReference(name, name+"-"+string(defaultArch), goldenImagesNamespace),
 		})
 
 		for _, arch := range dsArchs {
-			if !slices.Contains(clusterArchs, arch) {
+			if !slices.Contains(dsClusterArchs, arch) {
 				continue
 			}
 
 			dsName := name + "-" + string(arch)
-			dataSource := newDataSource(dsName)
+			dataSource := newDataSource(dsName, goldenImagesNamespace)
 			dataSource.Labels = map[string]string{
 				common_templates.TemplateArchitectureLabel: string(arch),
 			}
@@ -489,6 +744,12 @@ func getDataSourceInfosMultiArch(sourceCollection template_bundle.DataSourceColl
 				}
 			}
 
+			if !autoUpdateEnabled {
+				if err := keepMigratedDataSourceSource(dataSource, previousDataSources, request); err != nil {
+					return nil, err
+				}
+			}
+
 			var dicName string
 			if dic, ok := cronByDataSource[client.ObjectKeyFromObject(dataSource)]; ok {
 				dicName = dic.GetName()
@@ -574,6 +835,66 @@ func checkIfPvcExists(dataSource *cdiv1beta1.DataSource, request *common.Request
 	return true, nil
 }
 
+// getPreviousDataSources returns the owned DataSources that are not in the current
+// golden images namespace, because they were created before the namespace was changed.
+func getPreviousDataSources(request *common.Request) (map[string]*cdiv1beta1.DataSource, error) {
+	ownedDataSources, err := listAllOwnedDataSources(request)
+	if err != nil {
+		return nil, err
+	}
+
+	goldenImagesNamespace := common.GetGoldenImagesNamespace(&request.Instance.Spec)
+	previousDataSources := map[string]*cdiv1beta1.DataSource{}
+	for i := range ownedDataSources {
+		if ownedDataSources[i].Namespace != goldenImagesNamespace {
+			previousDataSources[ownedDataSources[i].Name] = &ownedDataSources[i]
+		}
+	}
+	return previousDataSources, nil
+}
+
+// keepMigratedDataSourceSource handles DataSources that are moved to a new golden images namespace.
+// Until the golden image PVC exists in the new namespace, the DataSource keeps pointing
+// to the PVC in the previous namespace, so VMs can still be created from it.
+func keepMigratedDataSourceSource(dataSource *cdiv1beta1.DataSource, previousDataSources map[string]*cdiv1beta1.DataSource, request *common.Request) error {
+	if dataSource.Spec.Source.PVC == nil {
+		return nil
+	}
+
+	var previousSource *cdiv1beta1.DataVolumeSourcePVC
+	foundDataSource := &cdiv1beta1.DataSource{}
+	err := request.Client.Get(request.Context, client.ObjectKeyFromObject(dataSource), foundDataSource)
+	switch {
+	case err == nil:
+		// The DataSource may have already been migrated in a previous reconciliation.
+		previousSource = foundDataSource.Spec.Source.PVC
+	case errors.IsNotFound(err):
+		previousDataSource, ok := previousDataSources[dataSource.Name]
+		if !ok {
+			return nil
+		}
+		readyCondition := getDataSourceReadyCondition(previousDataSource)
+		if readyCondition == nil || readyCondition.Status != core.ConditionTrue {
+			return nil
+		}
+		previousSource = previousDataSource.Spec.Source.PVC
+	default:
+		return err
+	}
+
+	if previousSource == nil || previousSource.Namespace == dataSource.Namespace {
+		return nil
+	}
+
+	pvcExists, err := checkIfPvcExists(dataSource, request)
+	if err != nil || pvcExists {
+		return err
+	}
+
+	dataSource.Spec.Source.PVC = previousSource.DeepCopy()
+	return nil
+}
+
 func handleDefaultDataSource(dataSource *cdiv1beta1.DataSource, autoUpdate bool, originalPvcName string, request *common.Request) (*cdiv1beta1.DataSource, bool, error) {
 	foundDataSource := &cdiv1beta1.DataSource{}
 	err := request.Client.Get(request.Context, client.ObjectKeyFromObject(dataSource), foundDataSource)
@@ -629,47 +950,108 @@ func reconcileDataSources(dataSourceInfos []dataSourceInfo, request *common.Requ
 		return nil, err
 	}
 
-	dsNames := make(map[string]struct{}, len(dataSourceInfos))
+	dataSourceKeys := make(map[client.ObjectKey]struct{}, len(dataSourceInfos))
 	var funcs []common.ReconcileFunc
 	for i := range dataSourceInfos {
 		dsInfo := dataSourceInfos[i] // Make a local copy
 		funcs = append(funcs, func(request *common.Request) (common.ReconcileResult, error) {
 			return reconcileDataSource(dsInfo, request)
 		})
-		dsNames[dsInfo.dataSource.GetName()] = struct{}{}
+		dataSourceKeys[client.ObjectKeyFromObject(dsInfo.dataSource)] = struct{}{}
 	}
 
-	// Remove owned DataSources that are not in the 'dataSourceInfos'
+	// Remove owned DataSources that are not in the 'dataSourceInfos',
+	// including DataSources in a previous golden images namespace.
 	for i := range ownedDataSources {
-		if _, isUsed := dsNames[ownedDataSources[i].GetName()]; isUsed {
+		if _, isUsed := dataSourceKeys[client.ObjectKeyFromObject(&ownedDataSources[i])]; isUsed {
 			continue
 		}
 
-		dataSource := ownedDataSources[i] // Make local copy
-		dataSource.Namespace = internal.GoldenImagesNamespace
-		funcs = append(funcs, func(request *common.Request) (common.ReconcileResult, error) {
-			if !dataSource.GetDeletionTimestamp().IsZero() {
-				return common.ResourceDeletedResult(&dataSource, common.OperationResultDeleted), nil
-			}
+		funcs = append(funcs, deleteResourceFunc(&ownedDataSources[i]))
+	}
 
-			err := request.Client.Delete(request.Context, &dataSource)
-			if errors.IsNotFound(err) {
-				return common.ReconcileResult{
-					Resource: &dataSource,
-				}, nil
-			}
-			if err != nil {
-				request.Logger.Error(err, fmt.Sprintf("Error deleting \"%s\": %s", dataSource.GetName(), err))
-				return common.ReconcileResult{}, err
-			}
+	return funcs, nil
+}
 
-			return common.ResourceDeletedResult(&dataSource, common.OperationResultDeleted), nil
-		})
+func deleteResourceFunc(resource client.Object) common.ReconcileFunc {
+	return func(request *common.Request) (common.ReconcileResult, error) {
+		if !resource.GetDeletionTimestamp().IsZero() {
+			return common.ResourceDeletedResult(resource, common.OperationResultDeleted), nil
+		}
+
+		err := request.Client.Delete(request.Context, resource)
+		if errors.IsNotFound(err) {
+			return common.ReconcileResult{
+				Resource: resource,
+			}, nil
+		}
+		if err != nil {
+			request.Logger.Error(err, fmt.Sprintf("Error deleting \"%s\": %s", resource.GetName(), err))
+			return common.ReconcileResult{}, err
+		}
+
+		return common.ResourceDeletedResult(resource, common.OperationResultDeleted), nil
+	}
+}
+
+// reconcilePreviousNamespaceResources removes the owned Roles, RoleBindings and NetworkPolicies
+// from a previous golden images namespace.
+func reconcilePreviousNamespaceResources(request *common.Request) ([]common.ReconcileFunc, error) {
+	previousResources, err := listPreviousNamespaceResources(request)
+	if err != nil {
+		return nil, err
 	}
 
+	var funcs []common.ReconcileFunc
+	for _, resource := range previousResources {
+		funcs = append(funcs, deleteResourceFunc(resource))
+	}
 	return funcs, nil
 }
 
+// listPreviousNamespaceResources returns the owned Roles, RoleBindings and NetworkPolicies that are not
+// in the current golden images namespace, because they were created before the namespace was changed.
+func listPreviousNamespaceResources(request *common.Request) ([]client.Object, error) {
+	appLabels := client.MatchingLabels{
+		common.AppKubernetesNameLabel:      operandName,
+		common.AppKubernetesComponentLabel: operandComponent.String(),
+		common.AppKubernetesManagedByLabel: common.AppKubernetesManagedByValue,
+	}
+
+	roles, err := common.ListOwnedResources[rbac.RoleList, rbac.Role](request, appLabels)
+	if err != nil {
+		return nil, err
+	}
+	roleBindings, err := common.ListOwnedResources[rbac.RoleBindingList, rbac.RoleBinding](request, appLabels)
+	if err != nil {
+		return nil, err
+	}
+	networkPolicies, err := common.ListOwnedResources[networkv1.NetworkPolicyList, networkv1.NetworkPolicy](request, appLabels)
+	if err != nil {
+		return nil, err
+	}
+
+	goldenImagesNamespace := common.GetGoldenImagesNamespace(&request.Instance.Spec)
+	var resources []client.Object
+	resources = appendOutsideNamespace(resources, roles, goldenImagesNamespace)
+	resources = appendOutsideNamespace(resources, roleBindings, goldenImagesNamespace)
+	resources = appendOutsideNamespace(resources, networkPolicies, goldenImagesNamespace)
+	return resources, nil
+}
+
+func appendOutsideNamespace[T any, PtrT interface {
+	*T
+	client.Object
+}](resources []client.Object, items []T, namespace string) []client.Object {
+	for i := range items {
+		item := PtrT(&items[i])
+		if item.GetNamespace() != namespace {
+			resources = append(resources, item)
+		}
+	}
+	return resources
+}
+
 func reconcileDataSource(dsInfo dataSourceInfo, request *common.Request) (common.ReconcileResult, error) {
 	return common.CreateOrUpdate(request).
 		ClusterResource(dsInfo.dataSource).
@@ -757,16 +1139,16 @@ func reconcileDataImportCrons(dataImportCrons []*cdiv1beta1.DataImportCron, requ
 }
 
 func listAllOwnedDataSources(request *common.Request) ([]cdiv1beta1.DataSource, error) {
-	return common.ListOwnedResources[cdiv1beta1.DataSourceList, cdiv1beta1.DataSource](request, client.InNamespace(internal.GoldenImagesNamespace))
+	return common.ListOwnedResources[cdiv1beta1.DataSourceList, cdiv1beta1.DataSource](request)
 }
 
 func listAllOwnedDataImportCrons(request *common.Request) ([]cdiv1beta1.DataImportCron, error) {
 	return common.ListOwnedResources[cdiv1beta1.DataImportCronList, cdiv1beta1.DataImportCron](request)
 }
 
-func (d *dataSources) reconcileNetworkPolicies() []common.ReconcileFunc {
+func (d *dataSources) reconcileNetworkPolicies(namespace string) []common.ReconcileFunc {
 	var funcs []common.ReconcileFunc
-	for _, policy := range newNetworkPolicies(internal.GoldenImagesNamespace, d.runningOnOpenShift) {
+	for _, policy := range newNetworkPolicies(namespace, d.runningOnOpenShift) {
 		funcs = append(funcs, func(request *common.Request) (common.ReconcileResult, error) {
 			return common.CreateOrUpdate(request).
 				ClusterResource(policy).
@@ -777,6 +1159,31 @@ func (d *dataSources) reconcileNetworkPolicies() []common.ReconcileFunc {
 	return funcs
 }
 
+// getDataSourceClusterArchs returns the cluster architectures used for the DataSource with the name.
+// The architectures are limited by the override of the DataSource, and its default architecture is moved first.
+func getDataSourceClusterArchs(name string, clusterArchs []architecture.Arch, overrides []ssp.DataSourceArchitectureOverride) []architecture.Arch {
+	overrideIdx := slices.IndexFunc(overrides, func(override ssp.DataSourceArchitectureOverride) bool {
+		return override.Name == name
+	})
+	if overrideIdx < 0 {
+		return clusterArchs
+	}
+	override := &overrides[overrideIdx]
+
+	var result []architecture.Arch
+	for _, arch := range clusterArchs {
+		if len(override.Architectures) > 0 && !slices.Contains(override.Architectures, string(arch)) {
+			continue
+		}
+		if string(arch) == override.DefaultArchitecture {
+			result = slices.Insert(result, 0, arch)
+		} else {
+			result = append(result, arch)
+		}
+	}
+	return result
+}
+
 func getDefaultDataSourceArch(clusterArchs, dataSourceArchs []architecture.Arch) architecture.Arch {
 	// Default arch is the first one that is defined in the SSP and in the common templates
 	for _, arch := range clusterArchs {
@@ -2,18 +2,26 @@ package data_sources
 
 import (
 	"context"
+	"maps"
 	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	v1 "k8s.io/api/core/v1"
+	networkv1 "k8s.io/api/networking/v1"
 	rbac "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
+	kubevirtv1 "kubevirt.io/api/core/v1"
 	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -28,6 +36,7 @@ import (
 	common_templates "kubevirt.io/ssp-operator/internal/operands/common-templates"
 	template_bundle "kubevirt.io/ssp-operator/internal/template-bundle"
 	. "kubevirt.io/ssp-operator/internal/test-utils"
+	metrics "kubevirt.io/ssp-operator/pkg/monitoring/metrics/ssp-operator"
 )
 
 var log = logf.Log.WithName("data-sources operand")
@@ -121,7 +130,60 @@ var _ = Describe("Data-Sources operand", func() {
 	It("should create view role binding", func() {
 		_, err := operand.Reconcile(&request)
 		Expect(err).ToNot(HaveOccurred())
-		ExpectResourceExists(newViewRoleBinding(internal.GoldenImagesNamespace), request)
+		ExpectResourceExists(newViewRoleBinding(internal.GoldenImagesNamespace, nil), request)
+	})
+
+	It("should grant view role to configured subjects", func() {
+		request.Instance.Spec.CommonTemplates.GoldenImageAccess = &ssp.GoldenImageAccess{
+			Viewers: []ssp.GoldenImageSubject{{
+				Kind: rbac.GroupKind,
+				Name: "tenant-a",
+			}, {
+				Kind:      rbac.ServiceAccountKind,
+				Name:      "builder",
+				Namespace: "tenant-b",
+			}},
+		}
+
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		roleBinding := &rbac.RoleBinding{}
+		key := client.ObjectKeyFromObject(newViewRoleBinding(internal.GoldenImagesNamespace, nil))
+		Expect(request.Client.Get(request.Context, key, roleBinding)).To(Succeed())
+		Expect(roleBinding.Subjects).To(ConsistOf(
+			rbac.Subject{
+				Kind:     rbac.GroupKind,
+				Name:     "tenant-a",
+				APIGroup: rbac.GroupName,
+			},
+			rbac.Subject{
+				Kind:      rbac.ServiceAccountKind,
+				Name:      "builder",
+				Namespace: "tenant-b",
+			}))
+	})
+
+	It("should restore default view role subjects", func() {
+		request.Instance.Spec.CommonTemplates.GoldenImageAccess = &ssp.GoldenImageAccess{}
+
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		roleBinding := &rbac.RoleBinding{}
+		key := client.ObjectKeyFromObject(newViewRoleBinding(internal.GoldenImagesNamespace, nil))
+		Expect(request.Client.Get(request.Context, key, roleBinding)).To(Succeed())
+		Expect(roleBinding.Subjects).To(BeEmpty())
+
+		request.Instance.Spec.CommonTemplates.GoldenImageAccess = nil
+		// The SSP controller clears the cache when the spec changes
+		request.VersionCache = common.VersionCache{}
+
+		_, err = operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(request.Client.Get(request.Context, key, roleBinding)).To(Succeed())
+		Expect(roleBinding.Subjects).To(Equal(newViewRoleBinding(internal.GoldenImagesNamespace, nil).Subjects))
 	})
 
 	It("should create edit role", func() {
@@ -594,6 +656,63 @@ var _ = Describe("Data-Sources operand", func() {
 			Expect(found.Spec.Source.PVC.Name).To(Equal(pvc.Name))
 		})
 
+		Context("with DataSource architecture override", func() {
+			expectReference := func(name, referenceName string) {
+				dataSource := &cdiv1beta1.DataSource{}
+				Expect(request.Client.Get(request.Context, client.ObjectKey{
+					Name:      name,
+					Namespace: internal.GoldenImagesNamespace,
+				}, dataSource)).To(Succeed())
+				Expect(dataSource.Spec.Source.DataSource).ToNot(BeNil())
+				Expect(dataSource.Spec.Source.DataSource.Name).To(Equal(referenceName))
+			}
+
+			It("should refer to the default architecture from override", func() {
+				request.Instance.Spec.CommonTemplates.DataSourceArchitectureOverrides = []ssp.DataSourceArchitectureOverride{{
+					Name:                centos8,
+					DefaultArchitecture: string(architecture.ARM64),
+				}}
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				expectReference(centos8, centos8+"-"+string(architecture.ARM64))
+				expectReference(win10, win10+"-"+string(architecture.AMD64))
+			})
+
+			It("should create DataSources only for architectures from override", func() {
+				request.Instance.Spec.CommonTemplates.DataSourceArchitectureOverrides = []ssp.DataSourceArchitectureOverride{{
+					Name:          centos8,
+					Architectures: []string{string(architecture.ARM64)},
+				}}
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				ExpectResourceExists(newDataSource(centos8+"-"+string(architecture.ARM64), internal.GoldenImagesNamespace), request)
+				for _, arch := range []architecture.Arch{architecture.AMD64, architecture.S390X} {
+					ExpectResourceNotExists(newDataSource(centos8+"-"+string(arch), internal.GoldenImagesNamespace), request)
+				}
+				expectReference(centos8, centos8+"-"+string(architecture.ARM64))
+
+				ExpectResourceExists(newDataSource(win10+"-"+string(architecture.AMD64), internal.GoldenImagesNamespace), request)
+			})
+
+			It("should not create DataSources if override has no architecture supported by cluster", func() {
+				request.Instance.Spec.Cluster.WorkloadArchitectures = []string{string(architecture.AMD64)}
+				request.Instance.Spec.CommonTemplates.DataSourceArchitectureOverrides = []ssp.DataSourceArchitectureOverride{{
+					Name:          centos8,
+					Architectures: []string{string(architecture.ARM64)},
+				}}
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				ExpectResourceNotExists(newDataSourceReference(centos8, centos8+"-"+string(architecture.AMD64), internal.GoldenImagesNamespace), request)
+				ExpectResourceNotExists(newDataSource(centos8+"-"+string(architecture.AMD64), internal.GoldenImagesNamespace), request)
+			})
+		})
+
 		Context("with DataImportCron template", func() {
 			var (
 				cronTemplate ssp.DataImportCronTemplate
@@ -661,6 +780,28 @@ var _ = Describe("Data-Sources operand", func() {
 					To(MatchError(errors.IsNotFound, "errors.IsNotFound"))
 			})
 
+			It("should create DataImportCrons only for architectures from override", func() {
+				request.Instance.Spec.CommonTemplates.DataSourceArchitectureOverrides = []ssp.DataSourceArchitectureOverride{{
+					Name:          centos8,
+					Architectures: []string{string(architecture.ARM64)},
+				}}
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				for _, arch := range cronArchs {
+					err := request.Client.Get(request.Context, client.ObjectKey{
+						Name:      cronTemplate.Name + "-" + string(arch),
+						Namespace: internal.GoldenImagesNamespace,
+					}, &cdiv1beta1.DataImportCron{})
+					if arch == architecture.ARM64 {
+						Expect(err).ToNot(HaveOccurred())
+					} else {
+						Expect(err).To(MatchError(errors.IsNotFound, "errors.IsNotFound"))
+					}
+				}
+			})
+
 			It("should remove DataImportCron when multi-arch is disabled", func() {
 				_, err := operand.Reconcile(&request)
 				Expect(err).ToNot(HaveOccurred())
@@ -820,6 +961,1159 @@ var _ = Describe("Data-Sources operand", func() {
 		})
 	})
 
+	Context("with golden images namespace", func() {
+		const goldenImagesNamespace = "custom-os-images"
+
+		It("should create resources in the namespace", func() {
+			request.Instance.Spec.CommonTemplates.GoldenImagesNamespace = goldenImagesNamespace
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceExists(newGoldenImagesNS(goldenImagesNamespace), request)
+			ExpectResourceExists(newViewRole(goldenImagesNamespace), request)
+			ExpectResourceExists(newViewRoleBinding(goldenImagesNamespace, nil), request)
+			for _, policy := range newNetworkPolicies(goldenImagesNamespace, false) {
+				ExpectResourceExists(policy, request)
+			}
+			for name := range dataSourceCollection.Names() {
+				ExpectResourceExists(newDataSource(name, goldenImagesNamespace), request)
+			}
+		})
+
+		It("should create DataImportCron in the namespace", func() {
+			request.Instance.Spec.CommonTemplates.GoldenImagesNamespace = goldenImagesNamespace
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: centos8,
+				},
+				Spec: cdiv1beta1.DataImportCronSpec{
+					ManagedDataSource: centos8,
+				},
+			}}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(request.Client.Get(request.Context, client.ObjectKey{
+				Name:      centos8,
+				Namespace: goldenImagesNamespace,
+			}, &cdiv1beta1.DataImportCron{})).To(Succeed())
+		})
+
+		It("should move DataSources when the namespace changes", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			request.Instance.Spec.CommonTemplates.GoldenImagesNamespace = goldenImagesNamespace
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for name := range dataSourceCollection.Names() {
+				ExpectResourceNotExists(testDataSource(name), request)
+				ExpectResourceExists(newDataSource(name, goldenImagesNamespace), request)
+			}
+		})
+
+		It("should remove roles and network policies from the previous namespace", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			request.Instance.Spec.CommonTemplates.GoldenImagesNamespace = goldenImagesNamespace
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceNotExists(newViewRole(internal.GoldenImagesNamespace), request)
+			ExpectResourceNotExists(newViewRoleBinding(internal.GoldenImagesNamespace, nil), request)
+			policies := &networkv1.NetworkPolicyList{}
+			Expect(request.Client.List(request.Context, policies, client.InNamespace(internal.GoldenImagesNamespace))).To(Succeed())
+			Expect(policies.Items).To(BeEmpty())
+
+			ExpectResourceExists(newViewRole(goldenImagesNamespace), request)
+			ExpectResourceExists(newViewRoleBinding(goldenImagesNamespace, nil), request)
+		})
+
+		It("should remove roles and network policies from the previous namespace on cleanup", func() {
+			request.CrdList = &crdListMock{dataImportCronCrd, dataSourceCrd}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			request.Instance.Spec.CommonTemplates.GoldenImagesNamespace = goldenImagesNamespace
+
+			_, err = operand.Cleanup(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceNotExists(newViewRole(internal.GoldenImagesNamespace), request)
+			ExpectResourceNotExists(newViewRoleBinding(internal.GoldenImagesNamespace, nil), request)
+			policies := &networkv1.NetworkPolicyList{}
+			Expect(request.Client.List(request.Context, policies, client.InNamespace(internal.GoldenImagesNamespace))).To(Succeed())
+			Expect(policies.Items).To(BeEmpty())
+		})
+
+		It("should keep DataSource pointing to PVC in previous namespace until PVC exists in the new namespace", func() {
+			Expect(request.Client.Create(request.Context, &v1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      centos8,
+					Namespace: internal.GoldenImagesNamespace,
+				},
+			})).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			// Update DataSource status to simulate CDI
+			previousDataSource := &cdiv1beta1.DataSource{}
+			Expect(request.Client.Get(request.Context, client.ObjectKeyFromObject(testDataSource(centos8)), previousDataSource)).To(Succeed())
+			previousDataSource.Status.Conditions = []cdiv1beta1.DataSourceCondition{{
+				Type: cdiv1beta1.DataSourceReady,
+				ConditionState: cdiv1beta1.ConditionState{
+					Status: v1.ConditionTrue,
+				},
+			}}
+			Expect(request.Client.Update(request.Context, previousDataSource)).To(Succeed())
+
+			request.Instance.Spec.CommonTemplates.GoldenImagesNamespace = goldenImagesNamespace
+
+			dsKey := client.ObjectKey{Name: centos8, Namespace: goldenImagesNamespace}
+			for range 2 {
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				dataSource := &cdiv1beta1.DataSource{}
+				Expect(request.Client.Get(request.Context, dsKey, dataSource)).To(Succeed())
+				Expect(dataSource.Spec.Source.PVC.Namespace).To(Equal(internal.GoldenImagesNamespace))
+			}
+
+			Expect(request.Client.Create(request.Context, &v1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      centos8,
+					Namespace: goldenImagesNamespace,
+				},
+			})).To(Succeed())
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			dataSource := &cdiv1beta1.DataSource{}
+			Expect(request.Client.Get(request.Context, dsKey, dataSource)).To(Succeed())
+			Expect(dataSource.Spec.Source.PVC.Namespace).To(Equal(goldenImagesNamespace))
+		})
+	})
+
+	Context("with DataSource aliases", func() {
+		getDataSource := func(name string) *cdiv1beta1.DataSource {
+			dataSource := &cdiv1beta1.DataSource{}
+			Expect(request.Client.Get(request.Context, client.ObjectKey{
+				Name:      name,
+				Namespace: internal.GoldenImagesNamespace,
+			}, dataSource)).To(Succeed())
+			return dataSource
+		}
+
+		expectReference := func(name, referenceName string) {
+			Expect(getDataSource(name).Spec.Source).To(Equal(cdiv1beta1.DataSourceSource{
+				DataSource: &cdiv1beta1.DataSourceRefSourceDataSource{
+					Name:      referenceName,
+					Namespace: internal.GoldenImagesNamespace,
+				},
+			}))
+		}
+
+		It("should create alias DataSource", func() {
+			request.Instance.Spec.CommonTemplates.DataSourceAliases = []ssp.DataSourceAlias{{
+				Name:       "centos-latest",
+				DataSource: centos8,
+			}}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			expectReference("centos-latest", centos8)
+		})
+
+		It("should create alias to DataSource managed by custom DataImportCron", func() {
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "custom-cron",
+				},
+				Spec: cdiv1beta1.DataImportCronSpec{
+					ManagedDataSource: "custom",
+				},
+			}}
+			request.Instance.Spec.CommonTemplates.DataSourceAliases = []ssp.DataSourceAlias{{
+				Name:       "custom-latest",
+				DataSource: "custom",
+			}}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			expectReference("custom-latest", "custom")
+		})
+
+		It("should refer to the architecture specific DataSource, if multi-arch is enabled", func() {
+			request.Instance.Spec.EnableMultipleArchitectures = ptr.To(true)
+			request.Instance.Spec.Cluster = &ssp.Cluster{
+				WorkloadArchitectures:     []string{string(architecture.AMD64), string(architecture.ARM64)},
+				ControlPlaneArchitectures: []string{string(architecture.AMD64)},
+			}
+			request.Instance.Spec.CommonTemplates.DataSourceAliases = []ssp.DataSourceAlias{{
+				Name:       "centos-latest",
+				DataSource: centos8,
+			}}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			expectReference("centos-latest", centos8+"-"+string(architecture.AMD64))
+		})
+
+		It("should ignore alias to DataSource that is not managed", func() {
+			request.Instance.Spec.CommonTemplates.DataSourceAliases = []ssp.DataSourceAlias{{
+				Name:       "unknown-latest",
+				DataSource: "unknown",
+			}}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceNotExists(newDataSourceReference("unknown-latest", "unknown", internal.GoldenImagesNamespace), request)
+		})
+
+		It("should not replace managed DataSource by alias", func() {
+			request.Instance.Spec.CommonTemplates.DataSourceAliases = []ssp.DataSourceAlias{{
+				Name:       win10,
+				DataSource: centos8,
+			}}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getDataSource(win10).Spec.Source).To(Equal(testDataSource(win10).Spec.Source))
+		})
+
+		It("should not create alias with the name of DataSource managed by custom DataImportCron", func() {
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "custom-cron",
+				},
+				Spec: cdiv1beta1.DataImportCronSpec{
+					ManagedDataSource: "custom",
+				},
+			}}
+			request.Instance.Spec.CommonTemplates.DataSourceAliases = []ssp.DataSourceAlias{{
+				Name:       "custom",
+				DataSource: centos8,
+			}}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceNotExists(newDataSourceReference("custom", centos8, internal.GoldenImagesNamespace), request)
+		})
+
+		It("should remove alias DataSource when it is removed from SSP CR", func() {
+			request.Instance.Spec.CommonTemplates.DataSourceAliases = []ssp.DataSourceAlias{{
+				Name:       "centos-latest",
+				DataSource: centos8,
+			}}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			expectReference("centos-latest", centos8)
+
+			request.Instance.Spec.CommonTemplates.DataSourceAliases = nil
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceNotExists(newDataSourceReference("centos-latest", centos8, internal.GoldenImagesNamespace), request)
+		})
+	})
+
+	Context("with disabled DataImportCron", func() {
+		var cronTemplate ssp.DataImportCronTemplate
+
+		cronKey := func(name string) client.ObjectKey {
+			return client.ObjectKey{
+				Name:      name,
+				Namespace: internal.GoldenImagesNamespace,
+			}
+		}
+
+		BeforeEach(func() {
+			cronTemplate = ssp.DataImportCronTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: centos8,
+				},
+				Spec: cdiv1beta1.DataImportCronSpec{
+					ManagedDataSource: centos8,
+					Template: cdiv1beta1.DataVolume{
+						Spec: cdiv1beta1.DataVolumeSpec{
+							Source: &cdiv1beta1.DataVolumeSource{
+								Registry: &cdiv1beta1.DataVolumeSourceRegistry{},
+							},
+						},
+					},
+				},
+			}
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{cronTemplate}
+		})
+
+		It("should not create DataImportCron", func() {
+			request.Instance.Spec.CommonTemplates.DisabledDataImportCrons = []ssp.DisabledDataImportCron{{
+				Name: centos8,
+			}}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(request.Client.Get(request.Context, cronKey(centos8), &cdiv1beta1.DataImportCron{})).
+				To(MatchError(errors.IsNotFound, "errors.IsNotFound"))
+
+			dataSource := &cdiv1beta1.DataSource{}
+			Expect(request.Client.Get(request.Context, cronKey(centos8), dataSource)).To(Succeed())
+			Expect(dataSource.Spec.Source).To(Equal(testDataSource(centos8).Spec.Source))
+			Expect(dataSource.Labels).ToNot(HaveKey(dataImportCronLabel))
+		})
+
+		It("should remove existing DataImportCron when it is disabled", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(request.Client.Get(request.Context, cronKey(centos8), &cdiv1beta1.DataImportCron{})).To(Succeed())
+
+			request.Instance.Spec.CommonTemplates.DisabledDataImportCrons = []ssp.DisabledDataImportCron{{
+				Name: centos8,
+			}}
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(request.Client.Get(request.Context, cronKey(centos8), &cdiv1beta1.DataImportCron{})).
+				To(MatchError(errors.IsNotFound, "errors.IsNotFound"))
+			ExpectResourceExists(testDataSource(centos8), request)
+		})
+
+		It("should disable DataImportCron only for one architecture", func() {
+			cronTemplate.Annotations = map[string]string{
+				DataImportCronArchsAnnotation: "amd64,arm64",
+			}
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{cronTemplate}
+			request.Instance.Spec.CommonTemplates.DisabledDataImportCrons = []ssp.DisabledDataImportCron{{
+				Name:         centos8,
+				Architecture: string(architecture.ARM64),
+			}}
+			request.Instance.Spec.EnableMultipleArchitectures = ptr.To(true)
+			request.Instance.Spec.Cluster = &ssp.Cluster{
+				WorkloadArchitectures:     []string{string(architecture.AMD64), string(architecture.ARM64)},
+				ControlPlaneArchitectures: []string{string(architecture.AMD64)},
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			amd64Name := centos8 + "-" + string(architecture.AMD64)
+			arm64Name := centos8 + "-" + string(architecture.ARM64)
+
+			Expect(request.Client.Get(request.Context, cronKey(amd64Name), &cdiv1beta1.DataImportCron{})).To(Succeed())
+			Expect(request.Client.Get(request.Context, cronKey(arm64Name), &cdiv1beta1.DataImportCron{})).
+				To(MatchError(errors.IsNotFound, "errors.IsNotFound"))
+
+			dataSource := &cdiv1beta1.DataSource{}
+			Expect(request.Client.Get(request.Context, cronKey(arm64Name), dataSource)).To(Succeed())
+			Expect(dataSource.Spec.Source.PVC).To(Equal(&cdiv1beta1.DataVolumeSourcePVC{
+				Name:      arm64Name,
+				Namespace: internal.GoldenImagesNamespace,
+			}))
+		})
+	})
+
+	Context("with golden image storage policy", func() {
+		var cronTemplate ssp.DataImportCronTemplate
+
+		getCron := func(name string) *cdiv1beta1.DataImportCron {
+			cron := &cdiv1beta1.DataImportCron{}
+			Expect(request.Client.Get(request.Context, client.ObjectKey{
+				Name:      name,
+				Namespace: internal.GoldenImagesNamespace,
+			}, cron)).To(Succeed())
+			return cron
+		}
+
+		BeforeEach(func() {
+			cronTemplate = ssp.DataImportCronTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: win10,
+				},
+				Spec: cdiv1beta1.DataImportCronSpec{
+					ManagedDataSource: win10,
+					Template: cdiv1beta1.DataVolume{
+						Spec: cdiv1beta1.DataVolumeSpec{
+							Source: &cdiv1beta1.DataVolumeSource{
+								Registry: &cdiv1beta1.DataVolumeSourceRegistry{},
+							},
+						},
+					},
+				},
+			}
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{cronTemplate}
+			request.Instance.Spec.CommonTemplates.GoldenImageStoragePolicy = &ssp.GoldenImageStoragePolicy{
+				StorageClassName: ptr.To("golden-images"),
+				AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteMany},
+				VolumeMode:       ptr.To(v1.PersistentVolumeBlock),
+				SizeOverrides: []ssp.GoldenImageSizeOverride{{
+					DataSourcePattern: "centos*",
+					Size:              resource.MustParse("10Gi"),
+				}, {
+					DataSourcePattern: "win*",
+					Size:              resource.MustParse("50Gi"),
+				}},
+			}
+		})
+
+		It("should apply policy to DataImportCron", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			storage := getCron(win10).Spec.Template.Spec.Storage
+			Expect(storage).ToNot(BeNil())
+			Expect(storage.StorageClassName).To(HaveValue(Equal("golden-images")))
+			Expect(storage.AccessModes).To(ConsistOf(v1.ReadWriteMany))
+			Expect(storage.VolumeMode).To(HaveValue(Equal(v1.PersistentVolumeBlock)))
+			Expect(storage.Resources.Requests.Storage().String()).To(Equal("50Gi"))
+		})
+
+		It("should not override values defined in DataImportCron template", func() {
+			cronTemplate.Spec.Template.Spec.Storage = &cdiv1beta1.StorageSpec{
+				StorageClassName: ptr.To("other"),
+				Resources: v1.VolumeResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceStorage: resource.MustParse("30Gi"),
+					},
+				},
+			}
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{cronTemplate}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			storage := getCron(win10).Spec.Template.Spec.Storage
+			Expect(storage.StorageClassName).To(HaveValue(Equal("other")))
+			Expect(storage.AccessModes).To(ConsistOf(v1.ReadWriteMany))
+			Expect(storage.Resources.Requests.Storage().String()).To(Equal("30Gi"))
+		})
+
+		It("should not apply policy to DataImportCron template using PVC API", func() {
+			cronTemplate.Spec.Template.Spec.PVC = &v1.PersistentVolumeClaimSpec{}
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{cronTemplate}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getCron(win10).Spec.Template.Spec.Storage).To(BeNil())
+		})
+
+		It("should match size override by DataSource name without architecture suffix", func() {
+			cronTemplate.Annotations = map[string]string{
+				DataImportCronArchsAnnotation: "amd64,arm64",
+			}
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{cronTemplate}
+			request.Instance.Spec.EnableMultipleArchitectures = ptr.To(true)
+			request.Instance.Spec.Cluster = &ssp.Cluster{
+				WorkloadArchitectures:     []string{string(architecture.AMD64), string(architecture.ARM64)},
+				ControlPlaneArchitectures: []string{string(architecture.AMD64)},
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, arch := range []architecture.Arch{architecture.AMD64, architecture.ARM64} {
+				storage := getCron(win10 + "-" + string(arch)).Spec.Template.Spec.Storage
+				Expect(storage.Resources.Requests.Storage().String()).To(Equal("50Gi"))
+			}
+		})
+	})
+
+	Context("with golden image import window", func() {
+		getScheduleFields := func(name string) []string {
+			cron := &cdiv1beta1.DataImportCron{}
+			Expect(request.Client.Get(request.Context, client.ObjectKey{
+				Name:      name,
+				Namespace: internal.GoldenImagesNamespace,
+			}, cron)).To(Succeed())
+
+			fields := strings.Fields(cron.Spec.Schedule)
+			Expect(fields).To(HaveLen(5))
+			return fields
+		}
+
+		expectInWindow := func(fields []string, allowedHours ...int) {
+			minute, err := strconv.Atoi(fields[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(minute).To(BeNumerically(">=", 0))
+			Expect(minute).To(BeNumerically("<", 60))
+
+			hour, err := strconv.Atoi(fields[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allowedHours).To(ContainElement(hour))
+		}
+
+		BeforeEach(func() {
+			var cronTemplates []ssp.DataImportCronTemplate
+			for _, dsName := range []string{centos8, win10} {
+				cronTemplates = append(cronTemplates, ssp.DataImportCronTemplate{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: dsName,
+					},
+					Spec: cdiv1beta1.DataImportCronSpec{
+						ManagedDataSource: dsName,
+						Schedule:          "0 3 * * 1",
+					},
+				})
+			}
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates = cronTemplates
+		})
+
+		It("should schedule imports in the window", func() {
+			request.Instance.Spec.CommonTemplates.GoldenImageImportWindow = &ssp.GoldenImageImportWindow{
+				StartHour: 22,
+				Hours:     4,
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, dsName := range []string{centos8, win10} {
+				fields := getScheduleFields(dsName)
+				expectInWindow(fields, 22, 23, 0, 1)
+				Expect(fields[2:]).To(Equal([]string{"*", "*", "1"}))
+			}
+		})
+
+		It("should spread imports of different DataSources", func() {
+			request.Instance.Spec.CommonTemplates.GoldenImageImportWindow = &ssp.GoldenImageImportWindow{
+				StartHour: 1,
+				Hours:     2,
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getScheduleFields(centos8)).ToNot(Equal(getScheduleFields(win10)))
+		})
+
+		It("should not change schedule between reconciliations", func() {
+			request.Instance.Spec.CommonTemplates.GoldenImageImportWindow = &ssp.GoldenImageImportWindow{
+				StartHour: 1,
+				Hours:     2,
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			fields := getScheduleFields(centos8)
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getScheduleFields(centos8)).To(Equal(fields))
+		})
+
+		It("should keep template schedule without window", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getScheduleFields(centos8)).To(Equal([]string{"0", "3", "*", "*", "1"}))
+		})
+
+		DescribeTable("should keep schedule that does not run at a single time", func(schedule string) {
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates[0].Spec.Schedule = schedule
+			request.Instance.Spec.CommonTemplates.GoldenImageImportWindow = &ssp.GoldenImageImportWindow{
+				StartHour: 22,
+				Hours:     4,
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			cron := &cdiv1beta1.DataImportCron{}
+			Expect(request.Client.Get(request.Context, client.ObjectKey{
+				Name:      centos8,
+				Namespace: internal.GoldenImagesNamespace,
+			}, cron)).To(Succeed())
+			Expect(cron.Spec.Schedule).To(Equal(schedule))
+		},
+			Entry("with hour step", "0 */12 * * *"),
+			Entry("with hour range", "15 1-5 * * *"),
+			Entry("with minute list", "0,30 2 * * *"),
+			Entry("with every minute", "* 2 * * *"),
+			Entry("with macro", "@daily"),
+			Entry("with six fields", "0 0 2 * * *"),
+		)
+
+		It("should schedule imports of all architectures in the window", func() {
+			cronTemplates := request.Instance.Spec.CommonTemplates.DataImportCronTemplates
+			cronTemplates[0].Annotations = map[string]string{
+				DataImportCronArchsAnnotation: "amd64,arm64",
+			}
+			request.Instance.Spec.EnableMultipleArchitectures = ptr.To(true)
+			request.Instance.Spec.Cluster = &ssp.Cluster{
+				WorkloadArchitectures:     []string{string(architecture.AMD64), string(architecture.ARM64)},
+				ControlPlaneArchitectures: []string{string(architecture.AMD64)},
+			}
+			request.Instance.Spec.CommonTemplates.GoldenImageImportWindow = &ssp.GoldenImageImportWindow{
+				StartHour: 3,
+				Hours:     1,
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, arch := range []architecture.Arch{architecture.AMD64, architecture.ARM64} {
+				expectInWindow(getScheduleFields(centos8+"-"+string(arch)), 3)
+			}
+		})
+	})
+
+	Context("with golden image registry mirrors", func() {
+		var cronTemplate ssp.DataImportCronTemplate
+
+		getCronRegistry := func(name string) *cdiv1beta1.DataVolumeSourceRegistry {
+			cron := &cdiv1beta1.DataImportCron{}
+			Expect(request.Client.Get(request.Context, client.ObjectKey{
+				Name:      name,
+				Namespace: internal.GoldenImagesNamespace,
+			}, cron)).To(Succeed())
+			return cron.Spec.Template.Spec.Source.Registry
+		}
+
+		setRegistryURL := func(url string) {
+			cronTemplate.Spec.Template.Spec.Source.Registry.URL = ptr.To(url)
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{cronTemplate}
+		}
+
+		BeforeEach(func() {
+			cronTemplate = ssp.DataImportCronTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: centos8,
+				},
+				Spec: cdiv1beta1.DataImportCronSpec{
+					ManagedDataSource: centos8,
+					Template: cdiv1beta1.DataVolume{
+						Spec: cdiv1beta1.DataVolumeSpec{
+							Source: &cdiv1beta1.DataVolumeSource{
+								Registry: &cdiv1beta1.DataVolumeSourceRegistry{
+									URL:       ptr.To("docker://quay.io/containerdisks/centos-stream:8"),
+									SecretRef: ptr.To("quay-secret"),
+								},
+							},
+						},
+					},
+				},
+			}
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{cronTemplate}
+			request.Instance.Spec.CommonTemplates.GoldenImageRegistryMirrors = []ssp.GoldenImageRegistryMirror{{
+				Source:        "quay.io/containerdisks",
+				Mirror:        "mirror.example.com/containerdisks",
+				CertConfigMap: "mirror-cert",
+			}, {
+				Source: "quay.io",
+				Mirror: "other-mirror.example.com",
+			}}
+		})
+
+		It("should replace registry URL with first matching mirror", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			registry := getCronRegistry(centos8)
+			Expect(registry.URL).To(HaveValue(Equal("docker://mirror.example.com/containerdisks/centos-stream:8")))
+			Expect(registry.CertConfigMap).To(HaveValue(Equal("mirror-cert")))
+			Expect(registry.SecretRef).To(HaveValue(Equal("quay-secret")))
+		})
+
+		It("should set secret of the mirror", func() {
+			request.Instance.Spec.CommonTemplates.GoldenImageRegistryMirrors[0].SecretRef = "mirror-secret"
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getCronRegistry(centos8).SecretRef).To(HaveValue(Equal("mirror-secret")))
+		})
+
+		It("should match only whole path components", func() {
+			setRegistryURL("docker://quay.io/containerdisks-dev/centos-stream:8")
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getCronRegistry(centos8).URL).To(HaveValue(Equal("docker://other-mirror.example.com/containerdisks-dev/centos-stream:8")))
+		})
+
+		It("should not change URL without matching mirror", func() {
+			setRegistryURL("docker://registry.example.com/centos-stream:8")
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			registry := getCronRegistry(centos8)
+			Expect(registry.URL).To(HaveValue(Equal("docker://registry.example.com/centos-stream:8")))
+			Expect(registry.CertConfigMap).To(BeNil())
+		})
+
+		It("should replace registry URL of all architectures", func() {
+			cronTemplate.Annotations = map[string]string{
+				DataImportCronArchsAnnotation: "amd64,arm64",
+			}
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{cronTemplate}
+			request.Instance.Spec.EnableMultipleArchitectures = ptr.To(true)
+			request.Instance.Spec.Cluster = &ssp.Cluster{
+				WorkloadArchitectures:     []string{string(architecture.AMD64), string(architecture.ARM64)},
+				ControlPlaneArchitectures: []string{string(architecture.AMD64)},
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, arch := range []architecture.Arch{architecture.AMD64, architecture.ARM64} {
+				registry := getCronRegistry(centos8 + "-" + string(arch))
+				Expect(registry.URL).To(HaveValue(Equal("docker://mirror.example.com/containerdisks/centos-stream:8")))
+			}
+		})
+	})
+
+	Context("orphaned golden images", func() {
+		const otherNamespace = "other-namespace"
+
+		newPvc := func(name string) *v1.PersistentVolumeClaim {
+			return &v1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: internal.GoldenImagesNamespace,
+				},
+			}
+		}
+
+		newSnapshot := func(name string) *unstructured.Unstructured {
+			snapshot := &unstructured.Unstructured{}
+			snapshot.SetAPIVersion("snapshot.storage.k8s.io/v1")
+			snapshot.SetKind(volumeSnapshotKind)
+			snapshot.SetName(name)
+			snapshot.SetNamespace(internal.GoldenImagesNamespace)
+			return snapshot
+		}
+
+		getOrphanKeys := func() []volumeKey {
+			orphans, err := getOrphanedGoldenImages(&request)
+			Expect(err).ToNot(HaveOccurred())
+			return slices.Collect(maps.Keys(orphans))
+		}
+
+		pvcKey := func(name string) volumeKey {
+			return volumeKey{kind: pvcKind, namespace: internal.GoldenImagesNamespace, name: name}
+		}
+
+		BeforeEach(func() {
+			// Golden image PVCs of DataSources from the bundle
+			Expect(request.Client.Create(request.Context, newPvc(centos8))).To(Succeed())
+			Expect(request.Client.Create(request.Context, newPvc(win10))).To(Succeed())
+		})
+
+		It("should report volumes not used by DataSources", func() {
+			Expect(request.Client.Create(request.Context, newPvc("old-image"))).To(Succeed())
+			Expect(request.Client.Create(request.Context, newSnapshot("old-snapshot"))).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getOrphanKeys()).To(ConsistOf(
+				pvcKey("old-image"),
+				volumeKey{kind: volumeSnapshotKind, namespace: internal.GoldenImagesNamespace, name: "old-snapshot"},
+			))
+		})
+
+		It("should not report volumes of DataImportCrons", func() {
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "custom-cron",
+				},
+				Spec: cdiv1beta1.DataImportCronSpec{
+					ManagedDataSource: "custom",
+				},
+			}}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			cron := &cdiv1beta1.DataImportCron{}
+			Expect(request.Client.Get(request.Context, client.ObjectKey{
+				Name:      "custom-cron",
+				Namespace: internal.GoldenImagesNamespace,
+			}, cron)).To(Succeed())
+			cron.Status.LastImportedPVC = &cdiv1beta1.DataVolumeSourcePVC{
+				Name:      "custom-last",
+				Namespace: internal.GoldenImagesNamespace,
+			}
+			cron.Status.CurrentImports = []cdiv1beta1.ImportStatus{{
+				DataVolumeName: "custom-current",
+			}}
+			Expect(request.Client.Update(request.Context, cron)).To(Succeed())
+
+			oldImport := newPvc("custom-old")
+			oldImport.Labels = map[string]string{dataImportCronLabel: "custom-cron"}
+			Expect(request.Client.Create(request.Context, oldImport)).To(Succeed())
+			Expect(request.Client.Create(request.Context, newPvc("custom-last"))).To(Succeed())
+			Expect(request.Client.Create(request.Context, newPvc("custom-current"))).To(Succeed())
+
+			removedCronImport := newPvc("removed-cron-import")
+			removedCronImport.Labels = map[string]string{dataImportCronLabel: "removed-cron"}
+			Expect(request.Client.Create(request.Context, removedCronImport)).To(Succeed())
+
+			Expect(getOrphanKeys()).To(ConsistOf(pvcKey("removed-cron-import")))
+		})
+
+		It("should not report PVCs being uploaded", func() {
+			pvc := newPvc("upload")
+			pvc.Annotations = map[string]string{cdiPodPhaseAnnotation: string(v1.PodRunning)}
+			Expect(request.Client.Create(request.Context, pvc)).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getOrphanKeys()).To(BeEmpty())
+		})
+
+		It("should not report PVCs with owner", func() {
+			pvc := newPvc("datavolume-target")
+			pvc.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: cdiv1beta1.SchemeGroupVersion.String(),
+				Kind:       "DataVolume",
+				Name:       "datavolume-target",
+				UID:        "1234",
+			}}
+			Expect(request.Client.Create(request.Context, pvc)).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getOrphanKeys()).To(BeEmpty())
+		})
+
+		It("should not report PVCs mounted by pod", func() {
+			Expect(request.Client.Create(request.Context, newPvc("mounted"))).To(Succeed())
+			Expect(request.Client.Create(request.Context, &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod",
+					Namespace: internal.GoldenImagesNamespace,
+				},
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{{
+						Name: "disk",
+						VolumeSource: v1.VolumeSource{
+							PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "mounted",
+							},
+						},
+					}},
+				},
+			})).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getOrphanKeys()).To(BeEmpty())
+		})
+
+		It("should not report PVCs used by VM", func() {
+			Expect(request.Client.Create(request.Context, newPvc("vm-disk"))).To(Succeed())
+			Expect(request.Client.Create(request.Context, newPvc("vm-datavolume"))).To(Succeed())
+			Expect(request.Client.Create(request.Context, &kubevirtv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "vm",
+					Namespace: internal.GoldenImagesNamespace,
+				},
+				Spec: kubevirtv1.VirtualMachineSpec{
+					Template: &kubevirtv1.VirtualMachineInstanceTemplateSpec{
+						Spec: kubevirtv1.VirtualMachineInstanceSpec{
+							Volumes: []kubevirtv1.Volume{{
+								Name: "disk",
+								VolumeSource: kubevirtv1.VolumeSource{
+									PersistentVolumeClaim: &kubevirtv1.PersistentVolumeClaimVolumeSource{
+										PersistentVolumeClaimVolumeSource: v1.PersistentVolumeClaimVolumeSource{
+											ClaimName: "vm-disk",
+										},
+									},
+								},
+							}, {
+								Name: "datavolume",
+								VolumeSource: kubevirtv1.VolumeSource{
+									DataVolume: &kubevirtv1.DataVolumeSource{
+										Name: "vm-datavolume",
+									},
+								},
+							}},
+						},
+					},
+				},
+			})).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getOrphanKeys()).To(BeEmpty())
+		})
+
+		It("should not delete orphaned volumes by default", func() {
+			Expect(request.Client.Create(request.Context, newPvc("old-image"))).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceExists(newPvc("old-image"), request)
+		})
+
+		Context("with Delete policy", func() {
+			BeforeEach(func() {
+				request.Instance.Spec.CommonTemplates.OrphanedGoldenImages = ssp.OrphanedGoldenImagesDelete
+				Expect(request.Client.Create(request.Context, newPvc("old-image"))).To(Succeed())
+			})
+
+			It("should delete orphaned volumes", func() {
+				Expect(request.Client.Create(request.Context, newSnapshot("old-snapshot"))).To(Succeed())
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				ExpectResourceNotExists(newPvc("old-image"), request)
+				Expect(request.Client.Get(request.Context, client.ObjectKey{
+					Name:      "old-snapshot",
+					Namespace: internal.GoldenImagesNamespace,
+				}, newSnapshot("old-snapshot"))).To(MatchError(errors.IsNotFound, "errors.IsNotFound"))
+
+				ExpectResourceExists(newPvc(centos8), request)
+				ExpectResourceExists(newPvc(win10), request)
+			})
+
+			It("should not delete recently created volumes", func() {
+				pvc := newPvc("new-image")
+				pvc.CreationTimestamp = metav1.Now()
+				Expect(request.Client.Create(request.Context, pvc)).To(Succeed())
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				ExpectResourceNotExists(newPvc("old-image"), request)
+				ExpectResourceExists(newPvc("new-image"), request)
+				Expect(getOrphanKeys()).To(ConsistOf(pvcKey("new-image")))
+			})
+
+			It("should not delete volumes again before the delete interval passes", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				ExpectResourceNotExists(newPvc("old-image"), request)
+
+				Expect(request.Client.Create(request.Context, newPvc("other-old-image"))).To(Succeed())
+
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				ExpectResourceExists(newPvc("other-old-image"), request)
+
+				operand.(*dataSources).lastOrphanDeletion = time.Now().Add(-orphanedGoldenImagesDeleteInterval)
+
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				ExpectResourceNotExists(newPvc("other-old-image"), request)
+			})
+
+			It("should not delete volume used as clone source by DataVolume", func() {
+				Expect(request.Client.Create(request.Context, &cdiv1beta1.DataVolume{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "clone",
+						Namespace: otherNamespace,
+					},
+					Spec: cdiv1beta1.DataVolumeSpec{
+						Source: &cdiv1beta1.DataVolumeSource{
+							PVC: &cdiv1beta1.DataVolumeSourcePVC{
+								Name:      "old-image",
+								Namespace: internal.GoldenImagesNamespace,
+							},
+						},
+					},
+				})).To(Succeed())
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				ExpectResourceExists(newPvc("old-image"), request)
+				Expect(getOrphanKeys()).To(ConsistOf(pvcKey("old-image")))
+			})
+
+			It("should not delete volume used as clone source by VM", func() {
+				Expect(request.Client.Create(request.Context, &kubevirtv1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "vm",
+						Namespace: otherNamespace,
+					},
+					Spec: kubevirtv1.VirtualMachineSpec{
+						DataVolumeTemplates: []kubevirtv1.DataVolumeTemplateSpec{{
+							Spec: cdiv1beta1.DataVolumeSpec{
+								Source: &cdiv1beta1.DataVolumeSource{
+									PVC: &cdiv1beta1.DataVolumeSourcePVC{
+										Name:      "old-image",
+										Namespace: internal.GoldenImagesNamespace,
+									},
+								},
+							},
+						}},
+					},
+				})).To(Succeed())
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				ExpectResourceExists(newPvc("old-image"), request)
+			})
+		})
+	})
+
+	Context("golden image metrics", func() {
+		var (
+			transitionTime = metav1.Unix(1000, 0)
+			importTime     = metav1.Unix(2000, 0)
+			executionTime  = metav1.Unix(3000, 0)
+		)
+
+		getGoldenImages := func() []metrics.GoldenImageStatus {
+			dsAndCrons, err := operand.(*dataSources).getDataSourcesAndCrons(&request)
+			Expect(err).ToNot(HaveOccurred())
+			images, err := getGoldenImageStatuses(dsAndCrons, &request)
+			Expect(err).ToNot(HaveOccurred())
+			return images
+		}
+
+		BeforeEach(func() {
+			request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: win10,
+				},
+				Spec: cdiv1beta1.DataImportCronSpec{
+					ManagedDataSource: win10,
+				},
+			}}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, dsName := range []string{centos8, win10} {
+				dataSource := &cdiv1beta1.DataSource{}
+				Expect(request.Client.Get(request.Context, client.ObjectKey{
+					Name:      dsName,
+					Namespace: internal.GoldenImagesNamespace,
+				}, dataSource)).To(Succeed())
+				dataSource.Status.Conditions = []cdiv1beta1.DataSourceCondition{{
+					Type: cdiv1beta1.DataSourceReady,
+					ConditionState: cdiv1beta1.ConditionState{
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: transitionTime,
+					},
+				}}
+				Expect(request.Client.Update(request.Context, dataSource)).To(Succeed())
+			}
+		})
+
+		It("should report ready DataSources", func() {
+			images := getGoldenImages()
+			Expect(images).To(ConsistOf(
+				metrics.GoldenImageStatus{
+					Name:                centos8,
+					Namespace:           internal.GoldenImagesNamespace,
+					Ready:               true,
+					ReadyTransitionTime: transitionTime.Time,
+				},
+				metrics.GoldenImageStatus{
+					Name:                win10,
+					Namespace:           internal.GoldenImagesNamespace,
+					Ready:               true,
+					ReadyTransitionTime: transitionTime.Time,
+					AutoUpdate:          true,
+				},
+			))
+		})
+
+		It("should report import status from DataImportCron", func() {
+			cron := &cdiv1beta1.DataImportCron{}
+			Expect(request.Client.Get(request.Context, client.ObjectKey{
+				Name:      win10,
+				Namespace: internal.GoldenImagesNamespace,
+			}, cron)).To(Succeed())
+
+			cron.Annotations[dataImportCronDesiredDigestAnnotation] = "sha256:1234"
+			cron.Status.LastImportTimestamp = &importTime
+			cron.Status.LastExecutionTimestamp = &executionTime
+			cron.Status.Conditions = []cdiv1beta1.DataImportCronCondition{{
+				Type: cdiv1beta1.DataImportCronUpToDate,
+				ConditionState: cdiv1beta1.ConditionState{
+					Status: v1.ConditionTrue,
+				},
+			}}
+			cron.Status.CurrentImports = []cdiv1beta1.ImportStatus{{
+				DataVolumeName: "win10-import",
+			}}
+			Expect(request.Client.Update(request.Context, cron)).To(Succeed())
+
+			Expect(request.Client.Create(request.Context, &cdiv1beta1.DataVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "win10-import",
+					Namespace: internal.GoldenImagesNamespace,
+				},
+				Status: cdiv1beta1.DataVolumeStatus{
+					RestartCount: 2,
+				},
+			})).To(Succeed())
+
+			images := getGoldenImages()
+			Expect(images).To(ContainElement(metrics.GoldenImageStatus{
+				Name:                win10,
+				Namespace:           internal.GoldenImagesNamespace,
+				Ready:               true,
+				ReadyTransitionTime: transitionTime.Time,
+				AutoUpdate:          true,
+				LastImportTime:      importTime.Time,
+				UpToDateTime:        executionTime.Time,
+				Digest:              "sha256:1234",
+				ImportFailures:      2,
+			}))
+		})
+
+		It("should report golden image outdated since the DataImportCron is not up to date", func() {
+			cron := &cdiv1beta1.DataImportCron{}
+			Expect(request.Client.Get(request.Context, client.ObjectKey{
+				Name:      win10,
+				Namespace: internal.GoldenImagesNamespace,
+			}, cron)).To(Succeed())
+
+			cron.Status.LastImportTimestamp = &importTime
+			cron.Status.LastExecutionTimestamp = &executionTime
+			cron.Status.Conditions = []cdiv1beta1.DataImportCronCondition{{
+				Type: cdiv1beta1.DataImportCronUpToDate,
+				ConditionState: cdiv1beta1.ConditionState{
+					Status:             v1.ConditionFalse,
+					LastTransitionTime: transitionTime,
+				},
+			}}
+			Expect(request.Client.Update(request.Context, cron)).To(Succeed())
+
+			images := getGoldenImages()
+			Expect(images).To(ContainElement(SatisfyAll(
+				HaveField("Name", win10),
+				HaveField("LastImportTime", importTime.Time),
+				HaveField("UpToDateTime", transitionTime.Time),
+				HaveField("Digest", BeEmpty()),
+			)))
+		})
+	})
+
 	It("should remove old DataImportCron when multi-arch is enabled", func() {
 		cronTemplate := ssp.DataImportCronTemplate{
 			ObjectMeta: metav1.ObjectMeta{
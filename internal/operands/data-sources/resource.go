@@ -6,9 +6,9 @@ import (
 	rbac "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
-	"kubevirt.io/ssp-operator/internal/networkpolicies"
 
-	"kubevirt.io/ssp-operator/internal"
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal/networkpolicies"
 )
 
 const (
@@ -16,34 +16,34 @@ const (
 	EditClusterRoleName = "os-images.kubevirt.io:edit"
 )
 
-func newDataSource(name string) *cdiv1beta1.DataSource {
+func newDataSource(name string, namespace string) *cdiv1beta1.DataSource {
 	return &cdiv1beta1.DataSource{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
-			Namespace: internal.GoldenImagesNamespace,
+			Namespace: namespace,
 		},
 		Spec: cdiv1beta1.DataSourceSpec{
 			Source: cdiv1beta1.DataSourceSource{
 				PVC: &cdiv1beta1.DataVolumeSourcePVC{
 					Name:      name,
-					Namespace: internal.GoldenImagesNamespace,
+					Namespace: namespace,
 				},
 			},
 		},
 	}
 }
 
-func newDataSourceReference(name string, referenceName string) *cdiv1beta1.DataSource {
+func newDataSourceReference(name string, referenceName string, namespace string) *cdiv1beta1.DataSource {
 	return &cdiv1beta1.DataSource{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
-			Namespace: internal.GoldenImagesNamespace,
+			Namespace: namespace,
 		},
 		Spec: cdiv1beta1.DataSourceSpec{
 			Source: cdiv1beta1.DataSourceSource{
 				DataSource: &cdiv1beta1.DataSourceRefSourceDataSource{
 					Name:      referenceName,
-					Namespace: internal.GoldenImagesNamespace,
+					Namespace: namespace,
 				},
 			},
 		},
@@ -104,13 +104,24 @@ func newViewRole(namespace string) *rbac.Role {
 	}
 }
 
-func newViewRoleBinding(namespace string) *rbac.RoleBinding {
+func newViewRoleBinding(namespace string, access *ssp.GoldenImageAccess) *rbac.RoleBinding {
 	return &rbac.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      ViewRoleName,
 			Namespace: namespace,
 		},
-		Subjects: []rbac.Subject{
+		Subjects: newViewRoleBindingSubjects(access),
+		RoleRef: rbac.RoleRef{
+			Kind:     "Role",
+			Name:     ViewRoleName,
+			APIGroup: rbac.GroupName,
+		},
+	}
+}
+
+func newViewRoleBindingSubjects(access *ssp.GoldenImageAccess) []rbac.Subject {
+	if access == nil {
+		return []rbac.Subject{
 			{
 				Kind:     rbac.GroupKind,
 				Name:     "system:authenticated",
@@ -121,13 +132,23 @@ func newViewRoleBinding(namespace string) *rbac.RoleBinding {
 				Name:     "system:serviceaccounts",
 				APIGroup: rbac.GroupName,
 			},
-		},
-		RoleRef: rbac.RoleRef{
-			Kind:     "Role",
-			Name:     ViewRoleName,
-			APIGroup: rbac.GroupName,
-		},
+		}
+	}
+
+	subjects := make([]rbac.Subject, 0, len(access.Viewers))
+	for _, viewer := range access.Viewers {
+		subject := rbac.Subject{
+			Kind: viewer.Kind,
+			Name: viewer.Name,
+		}
+		if viewer.Kind == rbac.ServiceAccountKind {
+			subject.Namespace = viewer.Namespace
+		} else {
+			subject.APIGroup = rbac.GroupName
+		}
+		subjects = append(subjects, subject)
 	}
+	return subjects
 }
 
 func newEditRole() *rbac.ClusterRole {
@@ -2,6 +2,7 @@ package template_validator
 
 import (
 	"encoding/json"
+	"fmt"
 
 	admission "k8s.io/api/admissionregistration/v1"
 	apps "k8s.io/api/apps/v1"
@@ -23,11 +24,17 @@ import (
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings,verbs=list;watch;create;update;delete
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=list;watch;create;update;delete
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=list;watch;create;update;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=list;watch;create;update;delete
 
 // RBAC for created roles
 // +kubebuilder:rbac:groups=template.openshift.io,resources=templates,verbs=list;watch
-// +kubebuilder:rbac:groups=kubevirt.io,resources=virtualmachines,verbs=list;watch
+// +kubebuilder:rbac:groups=kubevirt.io,resources=virtualmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=instancetype.kubevirt.io,resources=virtualmachineinstancetypes;virtualmachineclusterinstancetypes;virtualmachinepreferences;virtualmachineclusterpreferences,verbs=list;watch
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get
+// +kubebuilder:rbac:groups=ssp.kubevirt.io,resources=virtualmachinevalidationpolicies,verbs=list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 
 func WatchTypes() []operands.WatchType {
 	return []operands.WatchType{
@@ -45,10 +52,13 @@ func WatchClusterTypes() []operands.WatchType {
 		{Object: &rbac.ClusterRole{}},
 		{Object: &rbac.ClusterRoleBinding{}},
 		{Object: &admission.ValidatingWebhookConfiguration{}},
+		{Object: &admission.MutatingWebhookConfiguration{}},
 	}
 }
 
-type templateValidator struct{}
+type templateValidator struct {
+	summaryWriter string
+}
 
 func (t *templateValidator) Name() string {
 	return operandName
@@ -70,8 +80,9 @@ func (t *templateValidator) Reconcile(request *common.Request) ([]common.Reconci
 		reconcileService,
 		reconcilePrometheusService,
 		reconcileConfigMap,
-		reconcileDeployment,
+		t.reconcileDeployment,
 		reconcileValidatingWebhook,
+		reconcileMutatingWebhook,
 	}
 	if !request.IsSingleReplicaTopologyMode() {
 		funcs = append(funcs, reconcilePodDisruptionBudget)
@@ -85,13 +96,18 @@ func (t *templateValidator) Cleanup(request *common.Request) ([]common.CleanupRe
 		newClusterRole(),
 		newClusterRoleBinding(request.Namespace),
 		newValidatingWebhook(request.Namespace),
+		newMutatingWebhook(request.Namespace),
 	)
 }
 
 var _ operands.Operand = &templateValidator{}
 
-func New() operands.Operand {
-	return &templateValidator{}
+// New creates the template validator operand. The summaryWriter is the name of the user
+// allowed to update only the validation summary annotation of VMs violating the rules.
+func New(summaryWriter string) operands.Operand {
+	return &templateValidator{
+		summaryWriter: summaryWriter,
+	}
 }
 
 const (
@@ -134,7 +150,7 @@ func reconcilePrometheusService(request *common.Request) (common.ReconcileResult
 		Reconcile()
 }
 
-func reconcileDeployment(request *common.Request) (common.ReconcileResult, error) {
+func (t *templateValidator) reconcileDeployment(request *common.Request) (common.ReconcileResult, error) {
 	image := getTemplateValidatorImage()
 	if image == "" {
 		panic("Cannot reconcile without valid image name")
@@ -149,6 +165,8 @@ func reconcileDeployment(request *common.Request) (common.ReconcileResult, error
 	}
 
 	deployment := newDeployment(request.Namespace, numberOfReplicas, image)
+	container := &deployment.Spec.Template.Spec.Containers[0]
+	container.Args = append(container.Args, validatorArgs(validatorSpec, t.summaryWriter)...)
 	common.AddAppLabels(request.Instance, operandName, operandComponent, &deployment.Spec.Template.ObjectMeta)
 	injectPlacementMetadata(&deployment.Spec.Template.Spec, validatorSpec)
 	return common.CreateOrUpdate(request).
@@ -174,6 +192,31 @@ func reconcileConfigMap(request *common.Request) (common.ReconcileResult, error)
 		Reconcile()
 }
 
+// validatorArgs returns command line arguments that pass the SSP configuration to the validator
+func validatorArgs(validatorSpec *ssp.TemplateValidator, summaryWriter string) []string {
+	var args []string
+	if summaryWriter != "" {
+		args = append(args, fmt.Sprintf("--validation-summary-writer=%s", summaryWriter))
+	}
+	if validatorSpec == nil {
+		return args
+	}
+
+	if validatorSpec.Mode != "" {
+		args = append(args, fmt.Sprintf("--validation-mode=%s", validatorSpec.Mode))
+	}
+	if validatorSpec.AnnotationOverrides != "" {
+		args = append(args, fmt.Sprintf("--annotation-overrides=%s", validatorSpec.AnnotationOverrides))
+	}
+	if validatorSpec.MissingTemplates != "" {
+		args = append(args, fmt.Sprintf("--missing-templates=%s", validatorSpec.MissingTemplates))
+	}
+	if validatorSpec.StructuredRejectionCauses {
+		args = append(args, "--structured-rejection-causes")
+	}
+	return args
+}
+
 // Merge all Tolerations, Affinity and NodeSelectors from NodePlacement into pod spec
 func injectPlacementMetadata(podSpec *v1.PodSpec, componentConfig *ssp.TemplateValidator) {
 	if componentConfig == nil || componentConfig.Placement == nil {
@@ -262,6 +305,30 @@ func reconcileValidatingWebhook(request *common.Request) (common.ReconcileResult
 		Reconcile()
 }
 
+func reconcileMutatingWebhook(request *common.Request) (common.ReconcileResult, error) {
+	return common.CreateOrUpdate(request).
+		ClusterResource(newMutatingWebhook(request.Namespace)).
+		WithAppLabels(operandName, operandComponent).
+		UpdateFunc(func(newRes, foundRes client.Object) {
+			newWebhookConf := newRes.(*admission.MutatingWebhookConfiguration)
+			foundWebhookConf := foundRes.(*admission.MutatingWebhookConfiguration)
+
+			// Copy CA Bundle from the found webhook,
+			// so it will not be overwritten
+			for i := range newWebhookConf.Webhooks {
+				for j := range foundWebhookConf.Webhooks {
+					if newWebhookConf.Webhooks[i].Name == foundWebhookConf.Webhooks[j].Name {
+						newWebhookConf.Webhooks[i].ClientConfig.CABundle = foundWebhookConf.Webhooks[j].ClientConfig.CABundle
+						break
+					}
+				}
+			}
+
+			foundWebhookConf.Webhooks = newWebhookConf.Webhooks
+		}).
+		Reconcile()
+}
+
 func copyFoundCaBundles(newWebhooks []admission.ValidatingWebhook, foundWebhooks []admission.ValidatingWebhook) {
 	for i := range newWebhooks {
 		newWebhook := &newWebhooks[i]
@@ -16,6 +16,8 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/ptr"
+	kubevirt "kubevirt.io/api/core"
+	kubevirtv1 "kubevirt.io/api/core/v1"
 	lifecycleapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -31,14 +33,15 @@ var log = logf.Log.WithName("validator_operand")
 
 var _ = Describe("Template validator operand", func() {
 	const (
-		namespace       = "kubevirt"
-		name            = "test-ssp"
-		replicas  int32 = 2
+		namespace           = "kubevirt"
+		name                = "test-ssp"
+		replicas      int32 = 2
+		summaryWriter       = "system:serviceaccount:kubevirt:ssp-operator"
 	)
 
 	var (
 		request common.Request
-		operand = New()
+		operand = New(summaryWriter)
 	)
 
 	BeforeEach(func() {
@@ -89,6 +92,7 @@ var _ = Describe("Template validator operand", func() {
 		ExpectResourceExists(newConfigMap(namespace, ""), request)
 		ExpectResourceExists(newDeployment(namespace, replicas, "test-img"), request)
 		ExpectResourceExists(newValidatingWebhook(namespace), request)
+		ExpectResourceExists(newMutatingWebhook(namespace), request)
 		ExpectResourceExists(newPrometheusService(namespace), request)
 		ExpectResourceExists(newPodDisruptionBudget(namespace), request)
 		for _, policy := range newNetworkPolicies(namespace) {
@@ -96,6 +100,32 @@ var _ = Describe("Template validator operand", func() {
 		}
 	})
 
+	It("should validate all API versions of KubeVirt resources as v1", func() {
+		equivalent := admission.Equivalent
+		for _, webhook := range newValidatingWebhook(namespace).Webhooks {
+			for _, rule := range webhook.Rules {
+				if rule.APIGroups[0] == kubevirt.GroupName {
+					Expect(rule.APIVersions).To(Equal([]string{kubevirtv1.GroupVersion.Version}), "webhook %s", webhook.Name)
+					Expect(webhook.MatchPolicy).To(Equal(&equivalent), "webhook %s", webhook.Name)
+				}
+			}
+		}
+		for _, webhook := range newMutatingWebhook(namespace).Webhooks {
+			for _, rule := range webhook.Rules {
+				Expect(rule.APIVersions).To(Equal([]string{kubevirtv1.GroupVersion.Version}), "webhook %s", webhook.Name)
+			}
+			Expect(webhook.MatchPolicy).To(Equal(&equivalent), "webhook %s", webhook.Name)
+		}
+	})
+
+	It("should not fail VMI creation when the validator is not available", func() {
+		webhooks := newValidatingWebhook(namespace).Webhooks
+		Expect(webhooks).To(ContainElement(SatisfyAll(
+			HaveField("Name", "virtualmachineinstance-admission.ssp.kubevirt.io"),
+			HaveField("FailurePolicy", HaveValue(Equal(admission.Ignore))),
+		)))
+	})
+
 	It("should not create PodDisruptionBudget when SingleReplicaTopologyMode is used", func() {
 		request.TopologyMode = osconfv1.SingleReplicaTopologyMode
 
@@ -125,6 +155,26 @@ var _ = Describe("Template validator operand", func() {
 		Expect(updatedWebhook.Webhooks[0].ClientConfig.CABundle).To(Equal([]byte(testCaBundle)))
 	})
 
+	It("should not update mutating webhook CA bundle", func() {
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		key := client.ObjectKeyFromObject(newMutatingWebhook(namespace))
+		webhook := &admission.MutatingWebhookConfiguration{}
+		Expect(request.Client.Get(request.Context, key, webhook)).ToNot(HaveOccurred())
+
+		const testCaBundle = "testCaBundle"
+		webhook.Webhooks[0].ClientConfig.CABundle = []byte(testCaBundle)
+		Expect(request.Client.Update(request.Context, webhook)).ToNot(HaveOccurred())
+
+		_, err = operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		updatedWebhook := &admission.MutatingWebhookConfiguration{}
+		Expect(request.Client.Get(request.Context, key, updatedWebhook)).ToNot(HaveOccurred())
+		Expect(updatedWebhook.Webhooks[0].ClientConfig.CABundle).To(Equal([]byte(testCaBundle)))
+	})
+
 	It("should not update service cluster IP", func() {
 		_, err := operand.Reconcile(&request)
 		Expect(err).ToNot(HaveOccurred())
@@ -154,6 +204,7 @@ var _ = Describe("Template validator operand", func() {
 		ExpectResourceExists(newClusterRole(), request)
 		ExpectResourceExists(newClusterRoleBinding(namespace), request)
 		ExpectResourceExists(newValidatingWebhook(namespace), request)
+		ExpectResourceExists(newMutatingWebhook(namespace), request)
 
 		_, err = operand.Cleanup(&request)
 		Expect(err).ToNot(HaveOccurred())
@@ -161,6 +212,7 @@ var _ = Describe("Template validator operand", func() {
 		ExpectResourceNotExists(newClusterRole(), request)
 		ExpectResourceNotExists(newClusterRoleBinding(namespace), request)
 		ExpectResourceNotExists(newValidatingWebhook(namespace), request)
+		ExpectResourceNotExists(newMutatingWebhook(namespace), request)
 	})
 
 	It("should report status", func() {
@@ -423,6 +475,43 @@ var _ = Describe("Template validator operand", func() {
 		Expect(deployment.Annotations).To(HaveKeyWithValue(securityv1.RequiredSCCAnnotation, common.RequiredSCCAnnotationValue))
 		Expect(deployment.Spec.Template.Annotations).To(HaveKeyWithValue(securityv1.RequiredSCCAnnotation, common.RequiredSCCAnnotationValue))
 	})
+
+	It("should pass validation summary writer to the validator", func() {
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		key := client.ObjectKeyFromObject(newDeployment(namespace, replicas, "test-img"))
+		deployment := &apps.Deployment{}
+		Expect(request.Client.Get(request.Context, key, deployment)).To(Succeed())
+
+		Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--validation-summary-writer=" + summaryWriter))
+	})
+
+	DescribeTable("should pass configuration to the validator", func(updateFunc func(*ssp.TemplateValidator), expectedArg string) {
+		updateFunc(request.Instance.Spec.TemplateValidator)
+
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		key := client.ObjectKeyFromObject(newDeployment(namespace, replicas, "test-img"))
+		deployment := &apps.Deployment{}
+		Expect(request.Client.Get(request.Context, key, deployment)).To(Succeed())
+
+		Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElement(expectedArg))
+	},
+		Entry("validation mode", func(validator *ssp.TemplateValidator) {
+			validator.Mode = ssp.TemplateValidatorModeAudit
+		}, "--validation-mode=Audit"),
+		Entry("annotation overrides policy", func(validator *ssp.TemplateValidator) {
+			validator.AnnotationOverrides = ssp.AnnotationOverridesReject
+		}, "--annotation-overrides=Reject"),
+		Entry("missing templates policy", func(validator *ssp.TemplateValidator) {
+			validator.MissingTemplates = ssp.MissingTemplateReject
+		}, "--missing-templates=Reject"),
+		Entry("structured rejection causes", func(validator *ssp.TemplateValidator) {
+			validator.StructuredRejectionCauses = true
+		}, "--structured-rejection-causes"),
+	)
 })
 
 func updateDeploymentStatus(key client.ObjectKey, request *common.Request, updateFunc func(deploymentStatus *apps.DeploymentStatus)) {
@@ -7,6 +7,7 @@ import (
 	templatev1 "github.com/openshift/api/template/v1"
 	admission "k8s.io/api/admissionregistration/v1"
 	apps "k8s.io/api/apps/v1"
+	authorization "k8s.io/api/authorization/v1"
 	core "k8s.io/api/core/v1"
 	networkv1 "k8s.io/api/networking/v1"
 	policy "k8s.io/api/policy/v1"
@@ -17,12 +18,15 @@ import (
 	"k8s.io/utils/ptr"
 	kubevirt "kubevirt.io/api/core"
 	kubevirtv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/api/instancetype"
 
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
 	"kubevirt.io/ssp-operator/internal/common"
 	"kubevirt.io/ssp-operator/internal/env"
 	"kubevirt.io/ssp-operator/internal/networkpolicies"
 	common_templates "kubevirt.io/ssp-operator/internal/operands/common-templates"
 	"kubevirt.io/ssp-operator/internal/operands/metrics"
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
 	"kubevirt.io/ssp-operator/internal/template-validator/tlsinfo"
 	webhook "kubevirt.io/ssp-operator/internal/template-validator/webhooks"
 )
@@ -70,7 +74,32 @@ func newClusterRole() *rbac.ClusterRole {
 		}, {
 			APIGroups: []string{kubevirt.GroupName},
 			Resources: []string{"virtualmachines"},
+			Verbs:     []string{"get", "list", "watch"},
+		}, {
+			APIGroups: []string{instancetype.GroupName},
+			Resources: []string{
+				instancetype.PluralResourceName,
+				instancetype.ClusterPluralResourceName,
+				instancetype.PluralPreferenceResourceName,
+				instancetype.ClusterPluralPreferenceResourceName,
+			},
+			Verbs: []string{"list", "watch"},
+		}, {
+			APIGroups: []string{apps.GroupName},
+			Resources: []string{"controllerrevisions"},
+			Verbs:     []string{"get"},
+		}, {
+			APIGroups: []string{ssp.GroupVersion.Group},
+			Resources: []string{"virtualmachinevalidationpolicies"},
 			Verbs:     []string{"list", "watch"},
+		}, {
+			APIGroups: []string{core.GroupName},
+			Resources: []string{"events"},
+			Verbs:     []string{"create", "patch"},
+		}, {
+			APIGroups: []string{authorization.GroupName},
+			Resources: []string{"subjectaccessreviews"},
+			Verbs:     []string{"create"},
 		}},
 	}
 }
@@ -298,23 +327,28 @@ func newConfigMap(namespace string, tlsOptionsJson string) *core.ConfigMap {
 	}
 }
 
+// kubevirtWebhookRules matches the v1 version of the KubeVirt resource. The webhooks
+// use the Equivalent match policy, so objects created using other API versions
+// are converted to v1 by the API server and validated too.
+func kubevirtWebhookRules(resource string, operations ...admission.OperationType) []admission.RuleWithOperations {
+	return []admission.RuleWithOperations{{
+		Operations: operations,
+		Rule: admission.Rule{
+			APIGroups:   []string{kubevirt.GroupName},
+			APIVersions: []string{kubevirtv1.GroupVersion.Version},
+			Resources:   []string{resource},
+		},
+	}}
+}
+
 func newValidatingWebhook(serviceNamespace string) *admission.ValidatingWebhookConfiguration {
 	fail := admission.Fail
+	ignore := admission.Ignore
+	equivalent := admission.Equivalent
 	sideEffectsNone := admission.SideEffectClassNone
 
-	var vmRules []admission.RuleWithOperations
-	for _, version := range kubevirtv1.ApiSupportedWebhookVersions {
-		vmRules = append(vmRules, admission.RuleWithOperations{
-			Operations: []admission.OperationType{
-				admission.Create, admission.Update,
-			},
-			Rule: admission.Rule{
-				APIGroups:   []string{kubevirt.GroupName},
-				APIVersions: []string{version},
-				Resources:   []string{"virtualmachines"},
-			},
-		})
-	}
+	vmRules := kubevirtWebhookRules("virtualmachines", admission.Create, admission.Update)
+	vmiRules := kubevirtWebhookRules("virtualmachineinstances", admission.Create)
 
 	return &admission.ValidatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
@@ -333,6 +367,7 @@ func newValidatingWebhook(serviceNamespace string) *admission.ValidatingWebhookC
 				},
 			},
 			Rules:                   vmRules,
+			MatchPolicy:             &equivalent,
 			FailurePolicy:           &fail,
 			SideEffects:             &sideEffectsNone,
 			AdmissionReviewVersions: []string{"v1"},
@@ -363,6 +398,86 @@ func newValidatingWebhook(serviceNamespace string) *admission.ValidatingWebhookC
 			FailurePolicy:           &fail,
 			SideEffects:             &sideEffectsNone,
 			AdmissionReviewVersions: []string{"v1"},
+		}, {
+			Name: "virtualmachineinstance-admission.ssp.kubevirt.io",
+			ClientConfig: admission.WebhookClientConfig{
+				Service: &admission.ServiceReference{
+					Name:      ServiceName,
+					Namespace: serviceNamespace,
+					Path:      ptr.To(webhook.VmiValidatePath),
+				},
+			},
+			Rules:       vmiRules,
+			MatchPolicy: &equivalent,
+			// All VMIs in the cluster are sent to the webhook, including VMIs of VMs without
+			// templates and VMIs created for migrations. Their creation must not fail
+			// when the validator is not available.
+			FailurePolicy:           &ignore,
+			SideEffects:             &sideEffectsNone,
+			AdmissionReviewVersions: []string{"v1"},
+		}, {
+			Name: "virtualmachinevalidationpolicy-admission.ssp.kubevirt.io",
+			ClientConfig: admission.WebhookClientConfig{
+				Service: &admission.ServiceReference{
+					Name:      ServiceName,
+					Namespace: serviceNamespace,
+					Path:      ptr.To(webhook.ValidationPolicyValidatePath),
+				},
+			},
+			Rules: []admission.RuleWithOperations{{
+				Operations: []admission.OperationType{
+					admission.Create, admission.Update,
+				},
+				Rule: admission.Rule{
+					APIGroups:   []string{ssp.GroupVersion.Group},
+					APIVersions: []string{ssp.GroupVersion.Version},
+					Resources:   []string{"virtualmachinevalidationpolicies"},
+				},
+			}},
+			FailurePolicy:           &fail,
+			SideEffects:             &sideEffectsNone,
+			AdmissionReviewVersions: []string{"v1"},
+		}},
+	}
+}
+
+// newMutatingWebhook fills in default values of VMs created from templates.
+// Only templates with the apply-defaults annotation modify the VMs.
+func newMutatingWebhook(serviceNamespace string) *admission.MutatingWebhookConfiguration {
+	fail := admission.Fail
+	equivalent := admission.Equivalent
+	sideEffectsNone := admission.SideEffectClassNone
+
+	vmRules := kubevirtWebhookRules("virtualmachines", admission.Create)
+
+	return &admission.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: WebhookName,
+			Annotations: map[string]string{
+				"service.beta.openshift.io/inject-cabundle": "true",
+			},
+		},
+		Webhooks: []admission.MutatingWebhook{{
+			Name: "virtualmachine-defaults.ssp.kubevirt.io",
+			ClientConfig: admission.WebhookClientConfig{
+				Service: &admission.ServiceReference{
+					Name:      ServiceName,
+					Namespace: serviceNamespace,
+					Path:      ptr.To(webhook.VmMutatePath),
+				},
+			},
+			// Only VMs created from a template are modified
+			ObjectSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      labels.AnnotationTemplateNameKey,
+					Operator: metav1.LabelSelectorOpExists,
+				}},
+			},
+			Rules:                   vmRules,
+			MatchPolicy:             &equivalent,
+			FailurePolicy:           &fail,
+			SideEffects:             &sideEffectsNone,
+			AdmissionReviewVersions: []string{"v1"},
 		}},
 	}
 }
@@ -64,7 +64,8 @@ func CollectDataSources(templates []templatev1.Template) (DataSourceCollection,
 
 		namespace, exists := findDataSourceNamespace(template)
 		// This check is needed, so later code can assume that all DataSources
-		// should be created in the internal.GoldenImagesNamespace
+		// should be created in the internal.GoldenImagesNamespace, which the common
+		// templates operand replaces with the configured golden images namespace.
 		if exists && namespace != internal.GoldenImagesNamespace {
 			// If this happens, it is a programmer's error.
 			return nil, fmt.Errorf(
@@ -148,9 +149,8 @@ func findDataSourceName(template *templatev1.Template) (string, bool) {
 
 func findDataSourceNamespace(template *templatev1.Template) (string, bool) {
 	const dataSourceNamespaceOld = "SRC_PVC_NAMESPACE"
-	const dataSourceNamespace = "DATA_SOURCE_NAMESPACE"
 
-	name, exists := findParameterValue(dataSourceNamespace, template)
+	name, exists := findParameterValue(common_templates.TemplateDataSourceNamespaceParameterName, template)
 	if exists {
 		return name, true
 	}
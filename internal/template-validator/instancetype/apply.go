@@ -0,0 +1,162 @@
+package instancetype
+
+import (
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+)
+
+const (
+	defaultSpreadRatio   = 2
+	threadsPerCoreSpread = 2
+)
+
+// Apply expands the instancetype and the preference into the VMI spec,
+// so validation rules can be evaluated on the values the VM will run with.
+//
+// Only the parts of the spec that are relevant for validation rules are expanded.
+// Values from the instancetype take precedence over the VM spec, while preferences
+// only fill values that are not set on the VM.
+func Apply(vmiSpec *kubevirtv1.VirtualMachineInstanceSpec, instancetypeSpec *instancetypev1beta1.VirtualMachineInstancetypeSpec, preferenceSpec *instancetypev1beta1.VirtualMachinePreferenceSpec) {
+	if instancetypeSpec != nil {
+		applyCpu(vmiSpec, instancetypeSpec, preferenceSpec)
+		applyMemory(vmiSpec, instancetypeSpec)
+	}
+	if preferenceSpec != nil {
+		applyMachinePreferences(vmiSpec, preferenceSpec)
+		applyDevicePreferences(vmiSpec, preferenceSpec)
+	}
+}
+
+func applyCpu(vmiSpec *kubevirtv1.VirtualMachineInstanceSpec, instancetypeSpec *instancetypev1beta1.VirtualMachineInstancetypeSpec, preferenceSpec *instancetypev1beta1.VirtualMachinePreferenceSpec) {
+	if vmiSpec.Domain.CPU == nil {
+		vmiSpec.Domain.CPU = &kubevirtv1.CPU{}
+	}
+	cpu := vmiSpec.Domain.CPU
+
+	cpu.Sockets, cpu.Cores, cpu.Threads = cpuTopology(instancetypeSpec.CPU.Guest, preferenceSpec)
+
+	if instancetypeSpec.CPU.Model != nil {
+		cpu.Model = *instancetypeSpec.CPU.Model
+	}
+	if instancetypeSpec.CPU.DedicatedCPUPlacement != nil {
+		cpu.DedicatedCPUPlacement = *instancetypeSpec.CPU.DedicatedCPUPlacement
+	}
+	if instancetypeSpec.CPU.IsolateEmulatorThread != nil {
+		cpu.IsolateEmulatorThread = *instancetypeSpec.CPU.IsolateEmulatorThread
+	}
+	if instancetypeSpec.CPU.NUMA != nil {
+		cpu.NUMA = instancetypeSpec.CPU.NUMA.DeepCopy()
+	}
+}
+
+// cpuTopology returns the number of sockets, cores and threads for the guest vCPUs.
+func cpuTopology(vCpus uint32, preferenceSpec *instancetypev1beta1.VirtualMachinePreferenceSpec) (uint32, uint32, uint32) {
+	topology := instancetypev1beta1.Sockets
+	if preferenceSpec != nil && preferenceSpec.CPU != nil && preferenceSpec.CPU.PreferredCPUTopology != nil {
+		topology = *preferenceSpec.CPU.PreferredCPUTopology
+	}
+
+	switch topology {
+	case instancetypev1beta1.Cores, instancetypev1beta1.DeprecatedPreferCores:
+		return 1, vCpus, 1
+	case instancetypev1beta1.Threads, instancetypev1beta1.DeprecatedPreferThreads:
+		return 1, 1, vCpus
+	case instancetypev1beta1.Spread, instancetypev1beta1.DeprecatedPreferSpread:
+		if sockets, cores, threads, ok := spreadTopology(vCpus, preferenceSpec.CPU.SpreadOptions); ok {
+			return sockets, cores, threads
+		}
+	}
+	return vCpus, 1, 1
+}
+
+func spreadTopology(vCpus uint32, options *instancetypev1beta1.SpreadOptions) (uint32, uint32, uint32, bool) {
+	across := instancetypev1beta1.SpreadAcrossSocketsCores
+	ratio := uint32(defaultSpreadRatio)
+	if options != nil {
+		if options.Across != nil {
+			across = *options.Across
+		}
+		if options.Ratio != nil && *options.Ratio > 0 {
+			ratio = *options.Ratio
+		}
+	}
+
+	switch across {
+	case instancetypev1beta1.SpreadAcrossSocketsCores:
+		if vCpus%ratio == 0 {
+			return vCpus / ratio, ratio, 1, true
+		}
+	case instancetypev1beta1.SpreadAcrossCoresThreads:
+		if vCpus%threadsPerCoreSpread == 0 {
+			return 1, vCpus / threadsPerCoreSpread, threadsPerCoreSpread, true
+		}
+	case instancetypev1beta1.SpreadAcrossSocketsCoresThreads:
+		if vCpus%(ratio*threadsPerCoreSpread) == 0 {
+			return vCpus / (ratio * threadsPerCoreSpread), ratio, threadsPerCoreSpread, true
+		}
+	}
+	return 0, 0, 0, false
+}
+
+func applyMemory(vmiSpec *kubevirtv1.VirtualMachineInstanceSpec, instancetypeSpec *instancetypev1beta1.VirtualMachineInstancetypeSpec) {
+	if vmiSpec.Domain.Memory == nil {
+		vmiSpec.Domain.Memory = &kubevirtv1.Memory{}
+	}
+	memory := vmiSpec.Domain.Memory
+
+	guest := instancetypeSpec.Memory.Guest.DeepCopy()
+	memory.Guest = &guest
+
+	if instancetypeSpec.Memory.MaxGuest != nil {
+		maxGuest := instancetypeSpec.Memory.MaxGuest.DeepCopy()
+		memory.MaxGuest = &maxGuest
+	}
+	if instancetypeSpec.Memory.Hugepages != nil {
+		memory.Hugepages = instancetypeSpec.Memory.Hugepages.DeepCopy()
+	}
+}
+
+func applyMachinePreferences(vmiSpec *kubevirtv1.VirtualMachineInstanceSpec, preferenceSpec *instancetypev1beta1.VirtualMachinePreferenceSpec) {
+	if preferenceSpec.Machine == nil || preferenceSpec.Machine.PreferredMachineType == "" {
+		return
+	}
+	if vmiSpec.Domain.Machine == nil {
+		vmiSpec.Domain.Machine = &kubevirtv1.Machine{}
+	}
+	if vmiSpec.Domain.Machine.Type == "" {
+		vmiSpec.Domain.Machine.Type = preferenceSpec.Machine.PreferredMachineType
+	}
+}
+
+func applyDevicePreferences(vmiSpec *kubevirtv1.VirtualMachineInstanceSpec, preferenceSpec *instancetypev1beta1.VirtualMachinePreferenceSpec) {
+	devicePreferences := preferenceSpec.Devices
+	if devicePreferences == nil {
+		return
+	}
+
+	for i := range vmiSpec.Domain.Devices.Disks {
+		disk := &vmiSpec.Domain.Devices.Disks[i]
+		// A disk without a target is a disk device, see the KubeVirt defaulting.
+		if disk.Disk == nil && disk.CDRom == nil && disk.LUN == nil {
+			disk.Disk = &kubevirtv1.DiskTarget{}
+		}
+
+		switch {
+		case disk.Disk != nil && disk.Disk.Bus == "":
+			disk.Disk.Bus = devicePreferences.PreferredDiskBus
+		case disk.CDRom != nil && disk.CDRom.Bus == "":
+			disk.CDRom.Bus = devicePreferences.PreferredCdromBus
+		case disk.LUN != nil && disk.LUN.Bus == "":
+			disk.LUN.Bus = devicePreferences.PreferredLunBus
+		}
+	}
+
+	if devicePreferences.PreferredInterfaceModel != "" {
+		for i := range vmiSpec.Domain.Devices.Interfaces {
+			iface := &vmiSpec.Domain.Devices.Interfaces[i]
+			if iface.Model == "" {
+				iface.Model = devicePreferences.PreferredInterfaceModel
+			}
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package instancetype
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+)
+
+var _ = Describe("Apply", func() {
+	var (
+		vmiSpec          *kubevirtv1.VirtualMachineInstanceSpec
+		instancetypeSpec *instancetypev1beta1.VirtualMachineInstancetypeSpec
+		preferenceSpec   *instancetypev1beta1.VirtualMachinePreferenceSpec
+	)
+
+	BeforeEach(func() {
+		vmiSpec = &kubevirtv1.VirtualMachineInstanceSpec{}
+		instancetypeSpec = &instancetypev1beta1.VirtualMachineInstancetypeSpec{
+			CPU: instancetypev1beta1.CPUInstancetype{
+				Guest: 4,
+			},
+			Memory: instancetypev1beta1.MemoryInstancetype{
+				Guest: resource.MustParse("2Gi"),
+			},
+		}
+		preferenceSpec = &instancetypev1beta1.VirtualMachinePreferenceSpec{}
+	})
+
+	It("should apply memory from instancetype", func() {
+		Apply(vmiSpec, instancetypeSpec, nil)
+		Expect(vmiSpec.Domain.Memory).ToNot(BeNil())
+		Expect(vmiSpec.Domain.Memory.Guest).To(HaveValue(Equal(resource.MustParse("2Gi"))))
+	})
+
+	It("should expose vCPUs as sockets by default", func() {
+		Apply(vmiSpec, instancetypeSpec, nil)
+		Expect(vmiSpec.Domain.CPU).To(Equal(&kubevirtv1.CPU{Sockets: 4, Cores: 1, Threads: 1}))
+	})
+
+	DescribeTable("should use preferred CPU topology", func(topology instancetypev1beta1.PreferredCPUTopology, spreadOptions *instancetypev1beta1.SpreadOptions, sockets, cores, threads uint32) {
+		preferenceSpec.CPU = &instancetypev1beta1.CPUPreferences{
+			PreferredCPUTopology: ptr.To(topology),
+			SpreadOptions:        spreadOptions,
+		}
+		Apply(vmiSpec, instancetypeSpec, preferenceSpec)
+		Expect(vmiSpec.Domain.CPU.Sockets).To(Equal(sockets))
+		Expect(vmiSpec.Domain.CPU.Cores).To(Equal(cores))
+		Expect(vmiSpec.Domain.CPU.Threads).To(Equal(threads))
+	},
+		Entry("sockets", instancetypev1beta1.Sockets, nil, uint32(4), uint32(1), uint32(1)),
+		Entry("cores", instancetypev1beta1.Cores, nil, uint32(1), uint32(4), uint32(1)),
+		Entry("deprecated preferCores", instancetypev1beta1.DeprecatedPreferCores, nil, uint32(1), uint32(4), uint32(1)),
+		Entry("threads", instancetypev1beta1.Threads, nil, uint32(1), uint32(1), uint32(4)),
+		Entry("spread", instancetypev1beta1.Spread, nil, uint32(2), uint32(2), uint32(1)),
+		Entry("spread across cores and threads", instancetypev1beta1.Spread,
+			&instancetypev1beta1.SpreadOptions{Across: ptr.To(instancetypev1beta1.SpreadAcrossCoresThreads)},
+			uint32(1), uint32(2), uint32(2)),
+		Entry("spread with ratio not dividing vCPUs", instancetypev1beta1.Spread,
+			&instancetypev1beta1.SpreadOptions{Ratio: ptr.To[uint32](3)},
+			uint32(4), uint32(1), uint32(1)),
+	)
+
+	It("should keep CPU fields not defined by instancetype", func() {
+		vmiSpec.Domain.CPU = &kubevirtv1.CPU{Cores: 8, MaxSockets: 16}
+		Apply(vmiSpec, instancetypeSpec, nil)
+		Expect(vmiSpec.Domain.CPU).To(Equal(&kubevirtv1.CPU{Sockets: 4, Cores: 1, Threads: 1, MaxSockets: 16}))
+	})
+
+	It("should apply preferred machine type only if not set", func() {
+		preferenceSpec.Machine = &instancetypev1beta1.MachinePreferences{PreferredMachineType: "q35"}
+
+		Apply(vmiSpec, nil, preferenceSpec)
+		Expect(vmiSpec.Domain.Machine.Type).To(Equal("q35"))
+
+		vmiSpec.Domain.Machine.Type = "pc"
+		Apply(vmiSpec, nil, preferenceSpec)
+		Expect(vmiSpec.Domain.Machine.Type).To(Equal("pc"))
+	})
+
+	It("should apply preferred device buses and models", func() {
+		preferenceSpec.Devices = &instancetypev1beta1.DevicePreferences{
+			PreferredDiskBus:        kubevirtv1.DiskBusVirtio,
+			PreferredCdromBus:       kubevirtv1.DiskBusSATA,
+			PreferredInterfaceModel: "virtio",
+		}
+		vmiSpec.Domain.Devices = kubevirtv1.Devices{
+			Disks: []kubevirtv1.Disk{{
+				Name: "no-target",
+			}, {
+				Name:       "disk",
+				DiskDevice: kubevirtv1.DiskDevice{Disk: &kubevirtv1.DiskTarget{}},
+			}, {
+				Name:       "disk-with-bus",
+				DiskDevice: kubevirtv1.DiskDevice{Disk: &kubevirtv1.DiskTarget{Bus: kubevirtv1.DiskBusSCSI}},
+			}, {
+				Name:       "cdrom",
+				DiskDevice: kubevirtv1.DiskDevice{CDRom: &kubevirtv1.CDRomTarget{}},
+			}},
+			Interfaces: []kubevirtv1.Interface{{
+				Name: "default",
+			}},
+		}
+
+		Apply(vmiSpec, nil, preferenceSpec)
+
+		disks := vmiSpec.Domain.Devices.Disks
+		Expect(disks[0].Disk.Bus).To(Equal(kubevirtv1.DiskBusVirtio))
+		Expect(disks[1].Disk.Bus).To(Equal(kubevirtv1.DiskBusVirtio))
+		Expect(disks[2].Disk.Bus).To(Equal(kubevirtv1.DiskBusSCSI))
+		Expect(disks[3].CDRom.Bus).To(Equal(kubevirtv1.DiskBusSATA))
+		Expect(vmiSpec.Domain.Devices.Interfaces[0].Model).To(Equal("virtio"))
+	})
+})
@@ -19,6 +19,18 @@ const (
 	// VmSkipValidationAnnotationKey is used to skip validation of a VM.
 	// This annotation is used for troubleshooting, debugging and experimenting with templated VMs.
 	VmSkipValidationAnnotationKey string = "vm.kubevirt.io/skip-validations"
+
+	// VmValidationSummaryAnnotationKey contains a JSON summary of the last evaluation
+	// of the validation rules of a VM. It is written by the SSP operator.
+	VmValidationSummaryAnnotationKey string = "vm.kubevirt.io/validation-summary"
+
+	// AnnotationValidationModeKey is used on templates to override the validation mode
+	// configured for the whole validator. Supported values are "Enforce" and "Audit".
+	AnnotationValidationModeKey string = "template.kubevirt.io/validation-mode"
+
+	// AnnotationApplyDefaultsKey is used on templates to fill in values missing in VMs created
+	// from the template, before they are validated. The only supported value is "true".
+	AnnotationApplyDefaultsKey string = "template.kubevirt.io/apply-defaults"
 )
 
 type TemplateKey struct {
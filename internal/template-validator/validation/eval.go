@@ -19,14 +19,16 @@
 package validation
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k6tv1 "kubevirt.io/api/core/v1"
 
-	k6tobjs "kubevirt.io/ssp-operator/internal/template-validator/kubevirtjobs"
 	"kubevirt.io/ssp-operator/internal/template-validator/logger"
 )
 
@@ -37,12 +39,33 @@ var (
 	ErrUnsatisfiedRule      = errors.New("rule is not satisfied")
 )
 
+// CauseTypeRuleViolation is the type of the optional status causes describing a violated rule
+// in a machine-readable form. The message of such cause is a JSON encoded RuleViolation.
+const CauseTypeRuleViolation metav1.CauseType = "ValidationRuleViolation"
+
 type ValidationReport struct {
 	Ref       *Rule
-	Skipped   bool   // because not valid, with `valid` defined as per spec
-	Satisfied bool   // applied rule, with this result
-	Message   string // human-friendly application output (debug/troubleshooting)
-	Error     error  // *internal* error
+	Skipped   bool         // because not valid, with `valid` defined as per spec
+	Satisfied bool         // applied rule, with this result
+	Message   string       // human-friendly application output (debug/troubleshooting)
+	Details   *RuleDetails // machine-readable application output, set for applied rules
+	Error     error        // *internal* error
+}
+
+// RuleViolation describes a violated rule in a machine-readable form.
+type RuleViolation struct {
+	// Rule is the name of the rule.
+	Rule string `json:"rule"`
+	// Field is the path of the checked field in the VirtualMachine.
+	Field string `json:"field"`
+	// Message is the message of the rule.
+	Message string `json:"message"`
+	// Reason is the human-readable reason why the rule is violated.
+	Reason string `json:"reason,omitempty"`
+	// Error is set if the rule could not be applied.
+	Error string `json:"error,omitempty"`
+
+	*RuleDetails
 }
 
 type Result struct {
@@ -67,11 +90,12 @@ func (r *Result) Skip(ru *Rule) {
 	})
 }
 
-func (r *Result) Applied(ru *Rule, satisfied bool, message string) {
+func (r *Result) Applied(ru *Rule, satisfied bool, message string, details *RuleDetails) {
 	r.Status = append(r.Status, ValidationReport{
 		Ref:       ru,
 		Satisfied: satisfied,
 		Message:   message,
+		Details:   details,
 	})
 
 	if !satisfied {
@@ -101,6 +125,25 @@ func needsCause(rr *ValidationReport) (bool, string) {
 	return false, ""
 }
 
+// Violations returns the reports of rules that were not satisfied,
+// or that could not be applied because of an error.
+// It is empty if the evaluation succeeded.
+func (r *Result) Violations() []ValidationReport {
+	if !r.failed {
+		return nil
+	}
+
+	var violations []ValidationReport
+	for _, rr := range r.Status {
+		if ok, _ := needsCause(&rr); ok {
+			violations = append(violations, rr)
+		}
+	}
+	return violations
+}
+
+// ToStatusCauses returns a cause of type FieldValueInvalid for each violation. The causes use
+// the path of the field in the VirtualMachine, and have a human-readable message.
 func (r *Result) ToStatusCauses() []metav1.StatusCause {
 	if !r.failed {
 		return nil
@@ -111,7 +154,7 @@ func (r *Result) ToStatusCauses() []metav1.StatusCause {
 		if ok, message := needsCause(&rr); ok {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
-				Field:   rr.Ref.Path.Expr(),
+				Field:   rr.Ref.Path.FieldPath(),
 				Message: fmt.Sprintf("%s: %s", rr.Ref.Message, message),
 			})
 		}
@@ -119,6 +162,65 @@ func (r *Result) ToStatusCauses() []metav1.StatusCause {
 	return causes
 }
 
+// ToRuleViolationCauses returns a cause of type CauseTypeRuleViolation for each violation,
+// describing the violation in a machine-readable form.
+func (r *Result) ToRuleViolationCauses() []metav1.StatusCause {
+	if !r.failed {
+		return nil
+	}
+
+	var causes []metav1.StatusCause
+	for _, rr := range r.Status {
+		if ok, message := needsCause(&rr); ok {
+			causes = append(causes, metav1.StatusCause{
+				Type:    CauseTypeRuleViolation,
+				Field:   rr.Ref.Path.FieldPath(),
+				Message: rr.violation(message).String(),
+			})
+		}
+	}
+	return causes
+}
+
+// Message returns a human-readable message describing the violations.
+func (r *Result) Message() string {
+	if !r.failed {
+		return ""
+	}
+
+	var messages []string
+	for _, rr := range r.Status {
+		if ok, message := needsCause(&rr); ok {
+			messages = append(messages, fmt.Sprintf("%s: %s", rr.Ref.Message, message))
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (rr *ValidationReport) violation(reason string) *RuleViolation {
+	violation := &RuleViolation{
+		Rule:        rr.Ref.Name,
+		Field:       rr.Ref.Path.FieldPath(),
+		Message:     rr.Ref.Message,
+		RuleDetails: rr.Details,
+	}
+	if rr.Error != nil {
+		violation.Error = rr.Error.Error()
+	} else {
+		violation.Reason = reason
+	}
+	return violation
+}
+
+func (v *RuleViolation) String() string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		// Marshaling the struct cannot fail
+		return err.Error()
+	}
+	return string(data)
+}
+
 type Evaluator struct {
 	Sink io.Writer
 }
@@ -138,6 +240,29 @@ func validateRule(r *Rule) error {
 	return nil
 }
 
+// ValidateRules checks that the rules are well-formed, so they can be evaluated on any VM.
+func ValidateRules(rules []Rule) error {
+	uniqueNames := make(map[string]struct{}, len(rules))
+	for i := range rules {
+		r := &rules[i]
+		if _, ok := uniqueNames[r.Name]; ok {
+			return fmt.Errorf("rule %q: %w", r.Name, ErrDuplicateRuleName)
+		}
+		uniqueNames[r.Name] = struct{}{}
+
+		if err := validateRule(r); err != nil {
+			return fmt.Errorf("rule %q: %w", r.Name, err)
+		}
+
+		if r.Rule == RegexRule {
+			if _, err := regexp.Compile(r.Regex); err != nil {
+				return fmt.Errorf("rule %q: %w", r.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
 // Evaluate applies *all* the rules (greedy evaluation) to the given VM.
 // Returns a ValidationReport for each applied Rule, but ordering isn't guaranteed.
 // Use ValidationReport.Ref to crosslink ValidationReports with Rules.
@@ -150,8 +275,6 @@ func (ev *Evaluator) Evaluate(rules []Rule, vm *k6tv1.VirtualMachine) *Result {
 	uniqueNames := make(map[string]struct{})
 	result := Result{}
 
-	refVm := k6tobjs.NewDefaultVirtualMachine()
-
 	for i := range rules {
 		r := &rules[i]
 
@@ -184,7 +307,7 @@ func (ev *Evaluator) Evaluate(rules []Rule, vm *k6tv1.VirtualMachine) *Result {
 			continue
 		}
 
-		ra, err := r.Specialize(vm, refVm)
+		ra, err := r.Specialize(vm)
 		if err != nil {
 			// Ignoring returned error: This print is used only for logging
 			_, _ = fmt.Fprintf(ev.Sink, "%s failed: cannot specialize: %v\n", r.Name, err)
@@ -192,7 +315,7 @@ func (ev *Evaluator) Evaluate(rules []Rule, vm *k6tv1.VirtualMachine) *Result {
 			continue
 		}
 
-		satisfied, err := ra.Apply(vm, refVm)
+		satisfied, err := ra.Apply(vm)
 		if err != nil {
 			// Ignoring returned error: This print is used only for logging
 			_, _ = fmt.Fprintf(ev.Sink, "%s failed: cannot apply: %v\n", r.Name, err)
@@ -203,7 +326,7 @@ func (ev *Evaluator) Evaluate(rules []Rule, vm *k6tv1.VirtualMachine) *Result {
 		applicationText := ra.String()
 		// Ignoring returned error: This print is used only for logging
 		_, _ = fmt.Fprintf(ev.Sink, "%s applied: %v, %s\n", r.Name, boolAsStatus(satisfied), applicationText)
-		result.Applied(r, satisfied, applicationText)
+		result.Applied(r, satisfied, applicationText, ra.Details())
 	}
 
 	return &result
@@ -6,6 +6,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubevirtv1 "kubevirt.io/api/core/v1"
 
 	"kubevirt.io/ssp-operator/internal/template-validator/validation/path"
@@ -116,6 +117,38 @@ var _ = Describe("Eval", func() {
 		})
 	})
 
+	Context("ValidateRules", func() {
+		newRule := func(name string) Rule {
+			return Rule{
+				Name:    name,
+				Rule:    RegexRule,
+				Path:    *path.NewOrPanic("jsonpath::.spec.domain.machine.type"),
+				Message: "testing",
+				Regex:   "^q35$",
+			}
+		}
+
+		It("should accept valid rules", func() {
+			Expect(ValidateRules([]Rule{newRule("rule-1"), newRule("rule-2")})).To(Succeed())
+		})
+
+		It("should reject duplicate names", func() {
+			Expect(ValidateRules([]Rule{newRule("rule-1"), newRule("rule-1")})).To(MatchError(ErrDuplicateRuleName))
+		})
+
+		It("should reject invalid rule type", func() {
+			rule := newRule("rule-1")
+			rule.Rule = "unknown"
+			Expect(ValidateRules([]Rule{rule})).To(MatchError(ErrUnrecognizedRuleType))
+		})
+
+		It("should reject invalid regex", func() {
+			rule := newRule("rule-1")
+			rule.Regex = "q35["
+			Expect(ValidateRules([]Rule{rule})).To(MatchError(ContainSubstring("rule \"rule-1\"")))
+		})
+	})
+
 	Context("With an initialized VM object", func() {
 		var (
 			vmCirros *kubevirtv1.VirtualMachine
@@ -196,6 +229,134 @@ var _ = Describe("Eval", func() {
 			Expect(causes).To(HaveLen(1))
 		})
 
+		It("should describe violations in status causes", func() {
+			rules := []Rule{{
+				Rule:    IntegerRule,
+				Name:    "LimitCores",
+				Path:    *path.NewOrPanic("jsonpath::.spec.domain.cpu.cores"),
+				Message: "Core amount not within range",
+				Min:     &path.IntOrPath{Int: 1},
+				Max:     &path.IntOrPath{Int: 4},
+			}, {
+				Rule:    EnumRule,
+				Name:    "SupportedChipset",
+				Path:    *path.NewOrPanic("jsonpath::.spec.domain.machine.type"),
+				Message: "machine type must be a supported value",
+				Values:  []path.StringOrPath{{Str: "q35"}},
+			}}
+
+			vm := &kubevirtv1.VirtualMachine{
+				Spec: kubevirtv1.VirtualMachineSpec{
+					Template: &kubevirtv1.VirtualMachineInstanceTemplateSpec{
+						Spec: kubevirtv1.VirtualMachineInstanceSpec{
+							Domain: kubevirtv1.DomainSpec{
+								CPU:     &kubevirtv1.CPU{Cores: 8},
+								Machine: &kubevirtv1.Machine{Type: "pc"},
+							},
+						},
+					},
+				},
+			}
+
+			res := NewEvaluator().Evaluate(rules, vm)
+			Expect(res.Succeeded()).To(BeFalse())
+
+			causes := res.ToStatusCauses()
+			Expect(causes).To(Equal([]metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "spec.template.spec.domain.cpu.cores",
+				Message: "Core amount not within range: value 8 is higher than maximum [4]",
+			}, {
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "spec.template.spec.domain.machine.type",
+				Message: "machine type must be a supported value: Some of [pc] are not in [q35]",
+			}}))
+
+			Expect(res.Message()).To(Equal("Core amount not within range: value 8 is higher than maximum [4]; " +
+				"machine type must be a supported value: Some of [pc] are not in [q35]"))
+		})
+
+		It("should describe violations in rule violation causes", func() {
+			rules := []Rule{{
+				Rule:    IntegerRule,
+				Name:    "LimitCores",
+				Path:    *path.NewOrPanic("jsonpath::.spec.domain.cpu.cores"),
+				Message: "Core amount not within range",
+				Min:     &path.IntOrPath{Int: 1},
+				Max:     &path.IntOrPath{Int: 4},
+			}, {
+				Rule:    EnumRule,
+				Name:    "SupportedChipset",
+				Path:    *path.NewOrPanic("jsonpath::.spec.domain.machine.type"),
+				Message: "machine type must be a supported value",
+				Values:  []path.StringOrPath{{Str: "q35"}},
+			}}
+
+			vm := &kubevirtv1.VirtualMachine{
+				Spec: kubevirtv1.VirtualMachineSpec{
+					Template: &kubevirtv1.VirtualMachineInstanceTemplateSpec{
+						Spec: kubevirtv1.VirtualMachineInstanceSpec{
+							Domain: kubevirtv1.DomainSpec{
+								CPU:     &kubevirtv1.CPU{Cores: 8},
+								Machine: &kubevirtv1.Machine{Type: "pc"},
+							},
+						},
+					},
+				},
+			}
+
+			res := NewEvaluator().Evaluate(rules, vm)
+			Expect(res.Succeeded()).To(BeFalse())
+
+			causes := res.ToRuleViolationCauses()
+			Expect(causes).To(HaveLen(2))
+
+			Expect(causes[0].Type).To(Equal(CauseTypeRuleViolation))
+			Expect(causes[0].Field).To(Equal("spec.template.spec.domain.cpu.cores"))
+			Expect(causes[0].Message).To(MatchJSON(`{
+				"rule": "LimitCores",
+				"field": "spec.template.spec.domain.cpu.cores",
+				"message": "Core amount not within range",
+				"reason": "value 8 is higher than maximum [4]",
+				"values": ["8"],
+				"min": 1,
+				"max": 4
+			}`))
+
+			Expect(causes[1].Type).To(Equal(CauseTypeRuleViolation))
+			Expect(causes[1].Field).To(Equal("spec.template.spec.domain.machine.type"))
+			Expect(causes[1].Message).To(MatchJSON(`{
+				"rule": "SupportedChipset",
+				"field": "spec.template.spec.domain.machine.type",
+				"message": "machine type must be a supported value",
+				"reason": "Some of [pc] are not in [q35]",
+				"values": ["pc"],
+				"allowed": ["q35"]
+			}`))
+		})
+
+		It("should describe rules that cannot be applied in status causes", func() {
+			rules := []Rule{{
+				Rule:    IntegerRule,
+				Name:    "LimitCores",
+				Path:    *path.NewOrPanic("jsonpath::.spec.domain.cpu.cores"),
+				Message: "Core amount not within range",
+				Min:     &path.IntOrPath{Path: path.NewOrPanic("jsonpath::.spec.domain.this.does.not.exist")},
+			}}
+
+			res := NewEvaluator().Evaluate(rules, &kubevirtv1.VirtualMachine{})
+			Expect(res.Succeeded()).To(BeFalse())
+
+			causes := res.ToRuleViolationCauses()
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(MatchJSON(`{
+				"rule": "LimitCores",
+				"field": "spec.template.spec.domain.cpu.cores",
+				"message": "Core amount not within range",
+				"error": "invalid JSONPath"
+			}`))
+		})
+
 		It("should not fail, when justWarning is set", func() {
 			rules := []Rule{
 				{
@@ -94,6 +94,12 @@ func (p *Path) Expr() string {
 	return p.expr
 }
 
+// FieldPath returns the path of the field in the VirtualMachine, in the format used
+// by Kubernetes API errors, for example "spec.template.spec.domain.cpu.cores".
+func (p *Path) FieldPath() string {
+	return "spec.template" + p.expr
+}
+
 func (p *Path) Find(vm *k6tv1.VirtualMachine) (Results, error) {
 	results, err := p.path.FindResults(vm)
 	if err != nil {
@@ -102,6 +108,26 @@ func (p *Path) Find(vm *k6tv1.VirtualMachine) (Results, error) {
 	return results, nil
 }
 
+// Resolve finds the values the path points to in the VM. If the VM does not contain
+// the path, an optional field is missing, and it resolves to the zero value of its type.
+// Paths traversing lists resolve to no values, if the lists are empty or missing.
+// An error is returned if the path does not exist in the VirtualMachine schema.
+func (p *Path) Resolve(vm *k6tv1.VirtualMachine) (Results, error) {
+	results, err := p.Find(vm)
+	if err == nil && results.Len() > 0 {
+		return results, nil
+	}
+
+	info := schemaFor(p)
+	if !info.valid {
+		return nil, ErrInvalidJSONPath
+	}
+	if info.multiValued || info.leafType == nil {
+		return Results{}, nil
+	}
+	return Results{{reflect.Zero(info.leafType)}}, nil
+}
+
 func (p *Path) MarshalJSON() ([]byte, error) {
 	strVal := JSONPathPrefix + p.Expr()
 	return json.Marshal(strVal)
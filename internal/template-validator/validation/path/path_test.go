@@ -35,6 +35,11 @@ var _ = Describe("Path", func() {
 			}
 		})
 
+		It("Should return path of the field in the VM", func() {
+			p := NewOrPanic("jsonpath::.spec.domain.devices.interfaces[*].model")
+			Expect(p.FieldPath()).To(Equal("spec.template.spec.domain.devices.interfaces[*].model"))
+		})
+
 		It("Should mangle valid JSONPaths", func() {
 			expected := "{.spec.template.spec.domain.resources.requests.memory}"
 			testStrings := []string{
@@ -133,19 +138,99 @@ var _ = Describe("Path", func() {
 			Expect(vals[0]).To(Equal("q35"))
 		})
 
-		/* FIXME: the jsonpath package we use can't let us distinguish between:
-		   - bogus paths (e.g. paths which don't make sense in a VM object) and
-		   - uninitialized paths (e.g. legal paths but with a nil along the chain)
-		It("Should handle uninitialized paths", func() {
-			s := "jsonpath::.spec.domain.cpu.cores"
-			p, err := New(s)
-			Expect(p).To(Not(BeNil()))
-			Expect(err).To(BeNil())
+	})
+
+	Context("When resolving paths", func() {
+
+		var (
+			vmCirros *kubevirtv1.VirtualMachine
+		)
+
+		BeforeEach(func() {
+			vmCirros = test_utils.NewVMCirros()
+		})
+
+		It("Should resolve existing value", func() {
+			results, err := NewOrPanic("jsonpath::.spec.domain.machine.type").Resolve(vmCirros)
+			Expect(err).ToNot(HaveOccurred())
+
+			vals, err := results.AsString()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vals).To(Equal([]string{"q35"}))
+		})
+
+		It("Should resolve missing field to zero value", func() {
+			vmCirros.Spec.Template.Spec.Domain.CPU = nil
+
+			results, err := NewOrPanic("jsonpath::.spec.domain.cpu.cores").Resolve(vmCirros)
+			Expect(err).ToNot(HaveOccurred())
+
+			vals, err := results.AsInt64()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vals).To(Equal([]int64{0}))
+		})
+
+		It("Should resolve missing quantity to zero value", func() {
+			vmCirros.Spec.Template.Spec.Domain.Memory = nil
 
-			err = p.Find(vmCirros)
-			Expect(err).To(BeNil())
-			Expect(p.Len()).To(BeNumerically("=", 0))
+			results, err := NewOrPanic("jsonpath::.spec.domain.memory.guest").Resolve(vmCirros)
+			Expect(err).ToNot(HaveOccurred())
+
+			vals, err := results.AsInt64()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vals).To(Equal([]int64{0}))
+		})
+
+		It("Should resolve missing map key to zero value", func() {
+			vmCirros.Spec.Template.Spec.Domain.Resources.Requests = nil
+
+			results, err := NewOrPanic("jsonpath::.spec.domain.resources.requests.memory").Resolve(vmCirros)
+			Expect(err).ToNot(HaveOccurred())
+
+			vals, err := results.AsInt64()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vals).To(Equal([]int64{0}))
+		})
+
+		It("Should resolve missing list to no values", func() {
+			vmCirros.Spec.Template.Spec.Domain.Devices.Disks = nil
+
+			results, err := NewOrPanic("jsonpath::.spec.domain.devices.disks[*].disk.bus").Resolve(vmCirros)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results.Len()).To(BeZero())
+		})
+
+		It("Should resolve only existing fields of list items", func() {
+			vmCirros.Spec.Template.Spec.Domain.Devices.Disks = []kubevirtv1.Disk{{
+				Name: "disk",
+				DiskDevice: kubevirtv1.DiskDevice{
+					Disk: &kubevirtv1.DiskTarget{Bus: kubevirtv1.DiskBusVirtio},
+				},
+			}, {
+				Name: "cdrom",
+				DiskDevice: kubevirtv1.DiskDevice{
+					CDRom: &kubevirtv1.CDRomTarget{},
+				},
+			}}
+
+			results, err := NewOrPanic("jsonpath::.spec.domain.devices.disks[*].disk.bus").Resolve(vmCirros)
+			Expect(err).ToNot(HaveOccurred())
+
+			vals, err := results.AsString()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vals).To(Equal([]string{"virtio"}))
+		})
+
+		It("Should return error for path not in schema", func() {
+			_, err := NewOrPanic("jsonpath::.spec.domain.this.path.does.not.exist").Resolve(vmCirros)
+			Expect(err).To(Equal(ErrInvalidJSONPath))
+		})
+
+		It("Should return error for list index on a field that is not a list", func() {
+			vmCirros.Spec.Template.Spec.Domain.CPU = nil
+
+			_, err := NewOrPanic("jsonpath::.spec.domain.cpu[*].cores").Resolve(vmCirros)
+			Expect(err).To(Equal(ErrInvalidJSONPath))
 		})
-		*/
 	})
 })
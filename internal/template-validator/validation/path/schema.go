@@ -0,0 +1,167 @@
+package path
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/util/jsonpath"
+	k6tv1 "kubevirt.io/api/core/v1"
+)
+
+// The schema of a path is resolved by reflection over the Go types of the KubeVirt API,
+// starting at k6tv1.VirtualMachine and following the JSON field tags, including inlined
+// structs and the Go field name fallback, the same way the jsonpath library looks up fields.
+//
+// The KubeVirt OpenAPI definitions are not used, for these reasons:
+//   - Rules are evaluated by the jsonpath library on the decoded Go objects, so the Go types
+//     define which paths can have values. The schema cannot disagree with the evaluation.
+//   - A missing field resolves to the zero value of its Go type, see Path.Resolve, and the
+//     rules convert values based on their Go type, for example resource.Quantity.
+//     OpenAPI types like "string" or "integer" are not enough to create such values.
+//   - The generated definitions are part of kubevirt.io/client-go, which is not a dependency
+//     of this module, and reading them from the API server would make rule validation
+//     depend on the cluster.
+
+// schemaInfo describes what a path points to in the VirtualMachine schema.
+type schemaInfo struct {
+	// valid is false if the path refers to a field that does not exist in the schema.
+	valid bool
+	// leafType is the type of the values the path points to,
+	// or nil if it cannot be determined without an object.
+	leafType reflect.Type
+	// multiValued is true if the path can point to any number of values,
+	// for example because it traverses a list.
+	multiValued bool
+}
+
+var (
+	vmType = reflect.TypeOf(k6tv1.VirtualMachine{})
+
+	// schemaCache stores *schemaInfo for each path expression,
+	// because the number of distinct paths used by rules is small.
+	schemaCache sync.Map
+)
+
+func schemaFor(p *Path) *schemaInfo {
+	if info, ok := schemaCache.Load(p.expr); ok {
+		return info.(*schemaInfo)
+	}
+	info := resolveSchema(p.expr)
+	schemaCache.Store(p.expr, info)
+	return info
+}
+
+func resolveSchema(expr string) *schemaInfo {
+	pathExpr, err := NewJSONPathFromString(JSONPathPrefix + expr)
+	if err != nil {
+		return &schemaInfo{}
+	}
+	parser, err := jsonpath.Parse(expr, pathExpr)
+	if err != nil {
+		return &schemaInfo{}
+	}
+
+	info := &schemaInfo{valid: true}
+	info.leafType = walkSchema(vmType, parser.Root.Nodes, info)
+	if info.leafType != nil {
+		info.leafType = derefType(info.leafType)
+	}
+	return info
+}
+
+// walkSchema returns the type the nodes point to, starting from type t.
+// It returns nil if the type cannot be determined, and sets info.valid
+// to false if the nodes refer to a field that does not exist.
+func walkSchema(t reflect.Type, nodes []jsonpath.Node, info *schemaInfo) reflect.Type {
+	for _, node := range nodes {
+		if t == nil {
+			return nil
+		}
+
+		switch n := node.(type) {
+		case *jsonpath.ListNode:
+			t = walkSchema(t, n.Nodes, info)
+		case *jsonpath.FieldNode:
+			t = fieldType(t, n.Value)
+			if t == nil {
+				info.valid = false
+				return nil
+			}
+		case *jsonpath.ArrayNode, *jsonpath.FilterNode:
+			t = derefType(t)
+			if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+				info.valid = false
+				return nil
+			}
+			t = t.Elem()
+			info.multiValued = true
+		case *jsonpath.WildcardNode:
+			t = derefType(t)
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				t = t.Elem()
+				info.multiValued = true
+			case reflect.Struct:
+				// Fields of a struct have different types
+				info.multiValued = true
+				return nil
+			default:
+				info.valid = false
+				return nil
+			}
+		default:
+			// Recursive descent and unions can point to fields
+			// of different types, which cannot be determined without an object.
+			info.multiValued = true
+			return nil
+		}
+	}
+	return t
+}
+
+// fieldType returns the type of the field with the name in struct or map type t,
+// or nil if there is no such field.
+func fieldType(t reflect.Type, name string) reflect.Type {
+	t = derefType(t)
+	switch t.Kind() {
+	case reflect.Struct:
+		return structFieldType(t, name)
+	case reflect.Map:
+		if !reflect.TypeOf(name).ConvertibleTo(t.Key()) {
+			return nil
+		}
+		return t.Elem()
+	}
+	return nil
+}
+
+func structFieldType(t reflect.Type, name string) reflect.Type {
+	var inlineType reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if jsonName == name {
+			return field.Type
+		}
+		if jsonName == "" {
+			inlineType = field.Type
+		}
+	}
+	if inlineType != nil && inlineType.Kind() == reflect.Struct {
+		if fieldType := structFieldType(inlineType, name); fieldType != nil {
+			return fieldType
+		}
+	}
+	if field, ok := t.FieldByName(name); ok {
+		return field.Type
+	}
+	return nil
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,86 @@
+package validation
+
+import (
+	"regexp"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// RuleCache stores the parsed rules of objects, like templates, for each revision of the object.
+// This way the rules, their paths and regular expressions are not parsed and compiled again
+// every time they are evaluated. The returned rules are shared and must not be modified.
+type RuleCache struct {
+	lock    sync.RWMutex
+	entries map[types.UID]ruleCacheEntry
+}
+
+type ruleCacheEntry struct {
+	resourceVersion string
+	rules           []Rule
+	err             error
+}
+
+func NewRuleCache() *RuleCache {
+	return &RuleCache{
+		entries: map[types.UID]ruleCacheEntry{},
+	}
+}
+
+// Get returns the rules parsed from data, which is read from the object with the UID and resource version.
+// The data is parsed only if the cache does not contain rules for this revision of the object.
+// Objects without a UID or a resource version are never cached.
+func (c *RuleCache) Get(uid types.UID, resourceVersion string, data []byte) ([]Rule, error) {
+	if uid == "" || resourceVersion == "" {
+		return ParseRules(data)
+	}
+
+	c.lock.RLock()
+	entry, ok := c.entries[uid]
+	c.lock.RUnlock()
+	if ok && entry.resourceVersion == resourceVersion {
+		return entry.rules, entry.err
+	}
+
+	rules, err := ParseRules(data)
+	if err == nil {
+		compileRegexes(rules)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries[uid] = ruleCacheEntry{
+		resourceVersion: resourceVersion,
+		rules:           rules,
+		err:             err,
+	}
+	return rules, err
+}
+
+// Delete removes the rules of the object with the UID from the cache.
+func (c *RuleCache) Delete(uid types.UID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.entries, uid)
+}
+
+// Len returns the number of objects whose rules are cached.
+func (c *RuleCache) Len() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return len(c.entries)
+}
+
+// compileRegexes compiles the regular expressions of regex rules, so they are not compiled
+// when the rule is specialized. Invalid expressions are left to fail when the rule is evaluated.
+func compileRegexes(rules []Rule) {
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Rule != RegexRule {
+			continue
+		}
+		if regex, err := regexp.Compile(rule.Regex); err == nil {
+			rule.compiledRegex = regex
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package validation
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RuleCache", func() {
+	const (
+		uid = "test-uid"
+
+		regexRules = `[{
+			"name": "hostname",
+			"path": "jsonpath::.spec.template.spec.hostname",
+			"rule": "regex",
+			"message": "hostname must be lowercase",
+			"regex": "^[a-z]+$"
+		}]`
+		integerRules = `[{
+			"name": "core-limits",
+			"path": "jsonpath::.spec.template.spec.domain.cpu.cores",
+			"rule": "integer",
+			"message": "cpu cores must be limited",
+			"min": 1
+		}]`
+	)
+
+	var ruleCache *RuleCache
+
+	BeforeEach(func() {
+		ruleCache = NewRuleCache()
+	})
+
+	It("should return cached rules for the same revision", func() {
+		rules, err := ruleCache.Get(uid, "1", []byte(regexRules))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(1))
+		Expect(rules[0].compiledRegex).ToNot(BeNil())
+
+		cachedRules, err := ruleCache.Get(uid, "1", []byte(integerRules))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cachedRules).To(HaveLen(1))
+		Expect(&cachedRules[0]).To(BeIdenticalTo(&rules[0]))
+	})
+
+	It("should parse rules again for a new revision", func() {
+		_, err := ruleCache.Get(uid, "1", []byte(regexRules))
+		Expect(err).ToNot(HaveOccurred())
+
+		rules, err := ruleCache.Get(uid, "2", []byte(integerRules))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(1))
+		Expect(rules[0].Name).To(Equal("core-limits"))
+		Expect(ruleCache.Len()).To(Equal(1))
+	})
+
+	It("should cache parsing errors", func() {
+		_, err := ruleCache.Get(uid, "1", []byte("invalid json"))
+		Expect(err).To(HaveOccurred())
+
+		_, err = ruleCache.Get(uid, "1", []byte(integerRules))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not cache rules of objects without UID", func() {
+		_, err := ruleCache.Get("", "1", []byte(regexRules))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleCache.Len()).To(Equal(0))
+	})
+
+	It("should remove deleted rules", func() {
+		_, err := ruleCache.Get(uid, "1", []byte(regexRules))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleCache.Len()).To(Equal(1))
+
+		ruleCache.Delete(uid)
+		Expect(ruleCache.Len()).To(Equal(0))
+	})
+
+	It("should evaluate rules with invalid regex", func() {
+		rules, err := ruleCache.Get(uid, "1", []byte(`[{
+			"name": "hostname",
+			"path": "jsonpath::.spec.template.spec.hostname",
+			"rule": "regex",
+			"message": "hostname must be lowercase",
+			"regex": "[a-z"
+		}]`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules[0].compiledRegex).To(BeNil())
+
+		_, err = NewRegexRule(&rules[0])
+		Expect(err).To(HaveOccurred())
+	})
+})
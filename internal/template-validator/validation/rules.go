@@ -2,6 +2,7 @@ package validation
 
 import (
 	"encoding/json"
+	"regexp"
 
 	k6tv1 "kubevirt.io/api/core/v1"
 
@@ -24,6 +25,9 @@ type Rule struct {
 	MinLength *path.IntOrPath     `json:"minLength,omitempty"`
 	MaxLength *path.IntOrPath     `json:"maxLength,omitempty"`
 	Regex     string              `json:"regex,omitempty"`
+
+	// compiledRegex is set for rules stored in a RuleCache
+	compiledRegex *regexp.Regexp
 }
 
 func (r *Rule) IsAppliableOn(vm *k6tv1.VirtualMachine) bool {
@@ -54,23 +54,42 @@ func (r RuleType) IsValid() bool {
 }
 
 type RuleApplier interface {
-	Apply(vm, ref *k6tv1.VirtualMachine) (bool, error)
+	Apply(vm *k6tv1.VirtualMachine) (bool, error)
 	String() string
+	// Details returns the values the rule was applied on, and the values the rule expects.
+	Details() *RuleDetails
+}
+
+// RuleDetails describes an applied rule in a machine-readable form.
+// Only the fields relevant for the type of the rule are set.
+type RuleDetails struct {
+	// Values are the values found in the VM.
+	Values []string `json:"values,omitempty"`
+	// Min and Max are the bounds of integer rules.
+	Min *int64 `json:"min,omitempty"`
+	Max *int64 `json:"max,omitempty"`
+	// MinLength and MaxLength are the bounds of string rules.
+	MinLength *int64 `json:"minLength,omitempty"`
+	MaxLength *int64 `json:"maxLength,omitempty"`
+	// Allowed are the values allowed by enum rules.
+	Allowed []string `json:"allowed,omitempty"`
+	// Regex is the regular expression of regex rules.
+	Regex string `json:"regex,omitempty"`
 }
 
 var ErrNoValuesFound = errors.New("no values were found")
 
-// we need a vm reference to specialize a rule because few key fields may
+// we need the vm to specialize a rule because few key fields may
 // be JSONPath, and we need to walk them to get e.g. the value to check,
 // or the limits to enforce.
-func (r *Rule) Specialize(vm, ref *k6tv1.VirtualMachine) (RuleApplier, error) {
+func (r *Rule) Specialize(vm *k6tv1.VirtualMachine) (RuleApplier, error) {
 	switch r.Rule {
 	case IntegerRule:
-		return NewIntRule(r, vm, ref)
+		return NewIntRule(r, vm)
 	case StringRule:
-		return NewStringRule(r, vm, ref)
+		return NewStringRule(r, vm)
 	case EnumRule:
-		return NewEnumRule(r, vm, ref)
+		return NewEnumRule(r, vm)
 	case RegexRule:
 		return NewRegexRule(r)
 	}
@@ -84,9 +103,9 @@ type Range struct {
 	Max    int64
 }
 
-func (r *Range) Decode(min, max *path.IntOrPath, vm, ref *k6tv1.VirtualMachine) error {
+func (r *Range) Decode(min, max *path.IntOrPath, vm *k6tv1.VirtualMachine) error {
 	if min != nil {
-		v, err := decodeInt(*min, vm, ref)
+		v, err := decodeInt(*min, vm)
 		if err != nil {
 			return err
 		}
@@ -94,7 +113,7 @@ func (r *Range) Decode(min, max *path.IntOrPath, vm, ref *k6tv1.VirtualMachine)
 		r.MinSet = true
 	}
 	if max != nil {
-		v, err := decodeInt(*max, vm, ref)
+		v, err := decodeInt(*max, vm)
 		if err != nil {
 			return err
 		}
@@ -104,6 +123,18 @@ func (r *Range) Decode(min, max *path.IntOrPath, vm, ref *k6tv1.VirtualMachine)
 	return nil
 }
 
+// Bounds returns the minimum and maximum of the range, or nil if they are not set.
+func (r *Range) Bounds() (*int64, *int64) {
+	var min, max *int64
+	if r.MinSet {
+		min = &r.Min
+	}
+	if r.MaxSet {
+		max = &r.Max
+	}
+	return min, max
+}
+
 func (r *Range) Includes(v int64) bool {
 	if r.MinSet && v < r.Min {
 		return false
@@ -122,48 +153,25 @@ type intRule struct {
 	Satisfied bool
 }
 
-// JSONPATH lookup logic, aka what this "ref" object and why we need it
-//
-// When we need to fetch the value of a Rule.Path which happens to be a JSONPath,
-// first we just try if the given VM object has the path we need.
-// If the lookup succeeds, everyone's happy and we stop here.
-// Else, the vm obj has not the path we were looking for.
-// It could be either:
-// - the path is bogus. We check lazily, so this is the first time we see this
-//   and we need to make a decision. But mayne
-// - the path is legal, but it refers to an optional subpath which is missing.
-//   so we try again with the zero-initialized "reference" object.
-//   if even this lookup fails, we mark the path as bogus.
-//   Otherwise we use the zero, default, value for our logic.
-
-func findPathOnVmOrRef(path *path.Path, vm, ref *k6tv1.VirtualMachine) (path.Results, error) {
-	res, err := path.Find(vm)
-	if err == nil {
-		return res, nil
-	}
-
-	res, err = path.Find(ref)
-	if err == nil {
-		return res, nil
-	}
+// When we need to fetch the value of a Rule.Path, the path is resolved on the VM.
+// If the VM does not contain the path, the VirtualMachine schema is used to decide
+// whether the path is bogus, or it refers to an optional field which is missing.
+// Missing fields have the zero, default, value, and missing lists have no values.
 
-	return nil, err
-}
-
-func decodeInts(path *path.Path, vm, ref *k6tv1.VirtualMachine) ([]int64, error) {
-	res, err := findPathOnVmOrRef(path, vm, ref)
+func decodeInts(path *path.Path, vm *k6tv1.VirtualMachine) ([]int64, error) {
+	res, err := path.Resolve(vm)
 	if err != nil {
 		return nil, err
 	}
 	return res.AsInt64()
 }
 
-func decodeInt(ip path.IntOrPath, vm, ref *k6tv1.VirtualMachine) (int64, error) {
+func decodeInt(ip path.IntOrPath, vm *k6tv1.VirtualMachine) (int64, error) {
 	if ip.IsInt() {
 		return ip.Int, nil
 	}
 
-	v, err := decodeInts(ip.Path, vm, ref)
+	v, err := decodeInts(ip.Path, vm)
 	if err != nil {
 		return 0, err
 	}
@@ -173,20 +181,20 @@ func decodeInt(ip path.IntOrPath, vm, ref *k6tv1.VirtualMachine) (int64, error)
 	return v[0], nil
 }
 
-func decodeStrings(path *path.Path, vm, ref *k6tv1.VirtualMachine) ([]string, error) {
-	res, err := findPathOnVmOrRef(path, vm, ref)
+func decodeStrings(path *path.Path, vm *k6tv1.VirtualMachine) ([]string, error) {
+	res, err := path.Resolve(vm)
 	if err != nil {
 		return nil, err
 	}
 	return res.AsString()
 }
 
-func decodeString(sp path.StringOrPath, vm, ref *k6tv1.VirtualMachine) (string, error) {
+func decodeString(sp path.StringOrPath, vm *k6tv1.VirtualMachine) (string, error) {
 	if sp.IsString() {
 		return sp.Str, nil
 	}
 
-	vals, err := decodeStrings(sp.Path, vm, ref)
+	vals, err := decodeStrings(sp.Path, vm)
 	if err != nil {
 		return "", err
 	}
@@ -196,17 +204,17 @@ func decodeString(sp path.StringOrPath, vm, ref *k6tv1.VirtualMachine) (string,
 	return vals[0], nil
 }
 
-func NewIntRule(r *Rule, vm, ref *k6tv1.VirtualMachine) (RuleApplier, error) {
+func NewIntRule(r *Rule, vm *k6tv1.VirtualMachine) (RuleApplier, error) {
 	ir := intRule{Ref: r}
-	err := ir.Value.Decode(r.Min, r.Max, vm, ref)
+	err := ir.Value.Decode(r.Min, r.Max, vm)
 	if err != nil {
 		return nil, err
 	}
 	return &ir, nil
 }
 
-func (ir *intRule) Apply(vm, ref *k6tv1.VirtualMachine) (bool, error) {
-	vals, err := decodeInts(&ir.Ref.Path, vm, ref)
+func (ir *intRule) Apply(vm *k6tv1.VirtualMachine) (bool, error) {
+	vals, err := decodeInts(&ir.Ref.Path, vm)
 	if err != nil {
 		return false, err
 	}
@@ -256,6 +264,15 @@ func (ir *intRule) String() string {
 	return errorMessage
 }
 
+func (ir *intRule) Details() *RuleDetails {
+	details := &RuleDetails{}
+	for _, value := range ir.Current {
+		details.Values = append(details.Values, strconv.FormatInt(value, 10))
+	}
+	details.Min, details.Max = ir.Value.Bounds()
+	return details
+}
+
 type stringRule struct {
 	Ref       *Rule
 	Length    Range
@@ -263,17 +280,17 @@ type stringRule struct {
 	Satisfied bool
 }
 
-func NewStringRule(r *Rule, vm, ref *k6tv1.VirtualMachine) (RuleApplier, error) {
+func NewStringRule(r *Rule, vm *k6tv1.VirtualMachine) (RuleApplier, error) {
 	sr := stringRule{Ref: r}
-	err := sr.Length.Decode(r.MinLength, r.MaxLength, vm, ref)
+	err := sr.Length.Decode(r.MinLength, r.MaxLength, vm)
 	if err != nil {
 		return nil, err
 	}
 	return &sr, nil
 }
 
-func (sr *stringRule) Apply(vm, ref *k6tv1.VirtualMachine) (bool, error) {
-	vals, err := decodeStrings(&sr.Ref.Path, vm, ref)
+func (sr *stringRule) Apply(vm *k6tv1.VirtualMachine) (bool, error) {
+	vals, err := decodeStrings(&sr.Ref.Path, vm)
 	if err != nil {
 		return false, err
 	}
@@ -311,6 +328,12 @@ func (sr *stringRule) String() string {
 	}
 }
 
+func (sr *stringRule) Details() *RuleDetails {
+	details := &RuleDetails{Values: sr.Current}
+	details.MinLength, details.MaxLength = sr.Length.Bounds()
+	return details
+}
+
 type enumRule struct {
 	Ref       *Rule
 	Values    []string
@@ -318,10 +341,10 @@ type enumRule struct {
 	Satisfied bool
 }
 
-func NewEnumRule(r *Rule, vm, ref *k6tv1.VirtualMachine) (RuleApplier, error) {
+func NewEnumRule(r *Rule, vm *k6tv1.VirtualMachine) (RuleApplier, error) {
 	er := enumRule{Ref: r}
 	for _, v := range r.Values {
-		s, err := decodeString(v, vm, ref)
+		s, err := decodeString(v, vm)
 		if err != nil {
 			return nil, err
 		}
@@ -330,8 +353,8 @@ func NewEnumRule(r *Rule, vm, ref *k6tv1.VirtualMachine) (RuleApplier, error) {
 	return &er, nil
 }
 
-func (er *enumRule) Apply(vm, ref *k6tv1.VirtualMachine) (bool, error) {
-	vals, err := decodeStrings(&er.Ref.Path, vm, ref)
+func (er *enumRule) Apply(vm *k6tv1.VirtualMachine) (bool, error) {
+	vals, err := decodeStrings(&er.Ref.Path, vm)
 	if err != nil {
 		return false, err
 	}
@@ -369,6 +392,13 @@ func (er *enumRule) String() string {
 	}
 }
 
+func (er *enumRule) Details() *RuleDetails {
+	return &RuleDetails{
+		Values:  er.Current,
+		Allowed: er.Values,
+	}
+}
+
 type regexRule struct {
 	Ref       *Rule
 	Regex     *regexp.Regexp
@@ -377,9 +407,13 @@ type regexRule struct {
 }
 
 func NewRegexRule(r *Rule) (RuleApplier, error) {
-	regex, err := regexp.Compile(r.Regex)
-	if err != nil {
-		return nil, err
+	regex := r.compiledRegex
+	if regex == nil {
+		var err error
+		regex, err = regexp.Compile(r.Regex)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return &regexRule{
 		Ref:   r,
@@ -387,8 +421,8 @@ func NewRegexRule(r *Rule) (RuleApplier, error) {
 	}, nil
 }
 
-func (rr *regexRule) Apply(vm, ref *k6tv1.VirtualMachine) (bool, error) {
-	vals, err := decodeStrings(&rr.Ref.Path, vm, ref)
+func (rr *regexRule) Apply(vm *k6tv1.VirtualMachine) (bool, error) {
+	vals, err := decodeStrings(&rr.Ref.Path, vm)
 	if err != nil {
 		return false, err
 	}
@@ -416,3 +450,10 @@ func (rr *regexRule) String() string {
 		return fmt.Sprintf("Some of [%s] do not match %s", strings.Join(rr.Current, ", "), rr.Regex)
 	}
 }
+
+func (rr *regexRule) Details() *RuleDetails {
+	return &RuleDetails{
+		Values: rr.Current,
+		Regex:  rr.Regex.String(),
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	kubevirtv1 "kubevirt.io/api/core/v1"
 
-	k6tobjs "kubevirt.io/ssp-operator/internal/template-validator/kubevirtjobs"
 	"kubevirt.io/ssp-operator/internal/template-validator/validation/path"
 	test_utils "kubevirt.io/ssp-operator/internal/template-validator/validation/test-utils"
 )
@@ -17,12 +16,10 @@ var _ = Describe("Specialized", func() {
 
 		var (
 			vmCirros *kubevirtv1.VirtualMachine
-			vmRef    *kubevirtv1.VirtualMachine
 		)
 
 		BeforeEach(func() {
 			vmCirros = test_utils.NewVMCirros()
-			vmRef = k6tobjs.NewDefaultVirtualMachine()
 		})
 
 		It("Should apply simple integer rules", func() {
@@ -34,7 +31,7 @@ var _ = Describe("Specialized", func() {
 				Min:     &path.IntOrPath{Int: 64 * 1024 * 1024},
 				Max:     &path.IntOrPath{Int: 512 * 1024 * 1024},
 			}
-			expectRuleApplicationSuccess(&r, vmCirros, vmRef)
+			expectRuleApplicationSuccess(&r, vmCirros)
 		})
 
 		It("Should apply simple string rules", func() {
@@ -46,7 +43,7 @@ var _ = Describe("Specialized", func() {
 				MinLength: &path.IntOrPath{Int: 1},
 				MaxLength: &path.IntOrPath{Int: 32},
 			}
-			expectRuleApplicationSuccess(&r, vmCirros, vmRef)
+			expectRuleApplicationSuccess(&r, vmCirros)
 		})
 
 		It("Should apply simple enum rules", func() {
@@ -57,7 +54,7 @@ var _ = Describe("Specialized", func() {
 				Message: "machine type must be a supported value",
 				Values:  []path.StringOrPath{{Str: "q35"}, {Str: "440fx"}},
 			}
-			expectRuleApplicationSuccess(&r, vmCirros, vmRef)
+			expectRuleApplicationSuccess(&r, vmCirros)
 		})
 
 		It("Should apply enum rule to multiple values", func() {
@@ -68,7 +65,7 @@ var _ = Describe("Specialized", func() {
 				Message: "disk bus must be a supported value",
 				Values:  []path.StringOrPath{{Str: "virtio"}, {Str: "sata"}},
 			}
-			expectRuleApplicationSuccess(&r, vmCirros, vmRef)
+			expectRuleApplicationSuccess(&r, vmCirros)
 		})
 
 		It("Should apply simple regex rules", func() {
@@ -79,7 +76,7 @@ var _ = Describe("Specialized", func() {
 				Message: "machine type must be a supported value",
 				Regex:   "q35|440fx",
 			}
-			expectRuleApplicationSuccess(&r, vmCirros, vmRef)
+			expectRuleApplicationSuccess(&r, vmCirros)
 		})
 
 		It("Should apply regex rule to multiple values", func() {
@@ -90,7 +87,7 @@ var _ = Describe("Specialized", func() {
 				Message: "disk bus must be a supported value",
 				Regex:   "virtio|sata",
 			}
-			expectRuleApplicationSuccess(&r, vmCirros, vmRef)
+			expectRuleApplicationSuccess(&r, vmCirros)
 		})
 	})
 
@@ -98,12 +95,10 @@ var _ = Describe("Specialized", func() {
 
 		var (
 			vmCirros *kubevirtv1.VirtualMachine
-			vmRef    *kubevirtv1.VirtualMachine
 		)
 
 		BeforeEach(func() {
 			vmCirros = test_utils.NewVMCirros()
-			vmRef = k6tobjs.NewDefaultVirtualMachine()
 		})
 
 		It("Should detect bogus rules", func() {
@@ -117,7 +112,7 @@ var _ = Describe("Specialized", func() {
 				Max:     &path.IntOrPath{Int: 512 * 1024 * 1024},
 			}
 
-			ra, err := r.Specialize(vmCirros, vmRef)
+			ra, err := r.Specialize(vmCirros)
 			Expect(err).To(HaveOccurred())
 			Expect(ra).To(BeNil())
 		})
@@ -131,7 +126,7 @@ var _ = Describe("Specialized", func() {
 				Valid:   path.NewOrPanic("jsonpath::.spec.domain.this.path.does.not.exist"),
 				Min:     &path.IntOrPath{Int: 512 * 1024 * 1024},
 			}
-			expectRuleApplicationFailure(&r1, vmCirros, vmRef)
+			expectRuleApplicationFailure(&r1, vmCirros)
 
 			r2 := Rule{
 				Rule:    IntegerRule,
@@ -141,7 +136,7 @@ var _ = Describe("Specialized", func() {
 				Valid:   path.NewOrPanic("jsonpath::.spec.domain.this.path.does.not.exist"),
 				Max:     &path.IntOrPath{Int: 64 * 1024 * 1024},
 			}
-			expectRuleApplicationFailure(&r2, vmCirros, vmRef)
+			expectRuleApplicationFailure(&r2, vmCirros)
 		})
 
 		It("Should apply simple string rules", func() {
@@ -152,7 +147,7 @@ var _ = Describe("Specialized", func() {
 				Message:   "machine type must be specified",
 				MinLength: &path.IntOrPath{Int: 64},
 			}
-			expectRuleApplicationFailure(&r1, vmCirros, vmRef)
+			expectRuleApplicationFailure(&r1, vmCirros)
 
 			r2 := Rule{
 				Rule:      StringRule,
@@ -161,7 +156,7 @@ var _ = Describe("Specialized", func() {
 				Message:   "machine type must be specified",
 				MaxLength: &path.IntOrPath{Int: 1},
 			}
-			expectRuleApplicationFailure(&r2, vmCirros, vmRef)
+			expectRuleApplicationFailure(&r2, vmCirros)
 		})
 
 		It("Should apply simple enum rules", func() {
@@ -172,7 +167,7 @@ var _ = Describe("Specialized", func() {
 				Message: "machine type must be a supported value",
 				Values:  []path.StringOrPath{{Str: "foo"}, {Str: "bar"}},
 			}
-			expectRuleApplicationFailure(&r, vmCirros, vmRef)
+			expectRuleApplicationFailure(&r, vmCirros)
 		})
 
 		It("Should apply enum rule to multiple values", func() {
@@ -183,7 +178,7 @@ var _ = Describe("Specialized", func() {
 				Message: "disk bus must be a supported value",
 				Values:  []path.StringOrPath{{Str: "foo"}, {Str: "bar"}},
 			}
-			expectRuleApplicationFailure(&r, vmCirros, vmRef)
+			expectRuleApplicationFailure(&r, vmCirros)
 		})
 
 		It("Should error enum rule if values do not exist", func() {
@@ -195,7 +190,7 @@ var _ = Describe("Specialized", func() {
 				Message: "disk bus must be a supported value",
 				Values:  []path.StringOrPath{{Str: "virtio"}},
 			}
-			expectRuleApplicationError(&r, vmCirros, vmRef)
+			expectRuleApplicationError(&r, vmCirros)
 		})
 
 		It("Should apply simple regex rules", func() {
@@ -206,7 +201,7 @@ var _ = Describe("Specialized", func() {
 				Message: "machine type must be a supported value",
 				Regex:   "\\d[a-z]+\\d\\d",
 			}
-			expectRuleApplicationFailure(&r, vmCirros, vmRef)
+			expectRuleApplicationFailure(&r, vmCirros)
 		})
 
 		It("Should apply regex rule to multiple values", func() {
@@ -217,7 +212,7 @@ var _ = Describe("Specialized", func() {
 				Message: "disk bus must be a supported value",
 				Regex:   "foo|bar",
 			}
-			expectRuleApplicationFailure(&r, vmCirros, vmRef)
+			expectRuleApplicationFailure(&r, vmCirros)
 		})
 
 		It("Should error regex rule if values do not exist", func() {
@@ -229,7 +224,7 @@ var _ = Describe("Specialized", func() {
 				Message: "disk bus must be a supported value",
 				Regex:   "virtio|sata",
 			}
-			expectRuleApplicationError(&r, vmCirros, vmRef)
+			expectRuleApplicationError(&r, vmCirros)
 		})
 
 		It("Should post message when value is lower", func() {
@@ -245,11 +240,11 @@ var _ = Describe("Specialized", func() {
 				Min:     &path.IntOrPath{Int: 64 * 1024 * 1024},
 				Max:     &path.IntOrPath{Int: 512 * 1024 * 1024},
 			}
-			ra, err := r.Specialize(vmCirros, vmRef)
+			ra, err := r.Specialize(vmCirros)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(ra).To(Not(BeNil()))
 
-			ok, err := ra.Apply(vmCirros, vmRef)
+			ok, err := ra.Apply(vmCirros)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(ok).To(BeFalse())
 
@@ -270,11 +265,11 @@ var _ = Describe("Specialized", func() {
 				Min:     &path.IntOrPath{Int: 64 * 1024 * 1024},
 				Max:     &path.IntOrPath{Int: 512 * 1024 * 1024},
 			}
-			ra, err := r.Specialize(vmCirros, vmRef)
+			ra, err := r.Specialize(vmCirros)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(ra).To(Not(BeNil()))
 
-			ok, err := ra.Apply(vmCirros, vmRef)
+			ok, err := ra.Apply(vmCirros)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(ok).To(BeFalse())
 
@@ -295,11 +290,11 @@ var _ = Describe("Specialized", func() {
 				Min:     &path.IntOrPath{Int: 64 * 1024 * 1024},
 				Max:     &path.IntOrPath{Int: 512 * 1024 * 1024},
 			}
-			ra, err := r.Specialize(vmCirros, vmRef)
+			ra, err := r.Specialize(vmCirros)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(ra).To(Not(BeNil()))
 
-			ok, err := ra.Apply(vmCirros, vmRef)
+			ok, err := ra.Apply(vmCirros)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(ok).To(BeTrue())
 
@@ -310,29 +305,29 @@ var _ = Describe("Specialized", func() {
 
 })
 
-func expectRuleApplicationSuccess(r *Rule, vm, ref *kubevirtv1.VirtualMachine) {
-	checkRuleApplication(r, vm, ref, true)
+func expectRuleApplicationSuccess(r *Rule, vm *kubevirtv1.VirtualMachine) {
+	checkRuleApplication(r, vm, true)
 }
 
-func expectRuleApplicationFailure(r *Rule, vm, ref *kubevirtv1.VirtualMachine) {
-	checkRuleApplication(r, vm, ref, false)
+func expectRuleApplicationFailure(r *Rule, vm *kubevirtv1.VirtualMachine) {
+	checkRuleApplication(r, vm, false)
 }
 
-func expectRuleApplicationError(r *Rule, vm, ref *kubevirtv1.VirtualMachine) {
-	ra, err := r.Specialize(vm, ref)
+func expectRuleApplicationError(r *Rule, vm *kubevirtv1.VirtualMachine) {
+	ra, err := r.Specialize(vm)
 	Expect(err).ToNot(HaveOccurred())
 	Expect(ra).To(Not(BeNil()))
 
-	_, err = ra.Apply(vm, ref)
+	_, err = ra.Apply(vm)
 	Expect(err).To(HaveOccurred())
 }
 
-func checkRuleApplication(r *Rule, vm, ref *kubevirtv1.VirtualMachine, expected bool) {
-	ra, err := r.Specialize(vm, ref)
+func checkRuleApplication(r *Rule, vm *kubevirtv1.VirtualMachine, expected bool) {
+	ra, err := r.Specialize(vm)
 	Expect(err).ToNot(HaveOccurred())
 	Expect(ra).To(Not(BeNil()))
 
-	ok, err := ra.Apply(vm, ref)
+	ok, err := ra.Apply(vm)
 	Expect(err).ToNot(HaveOccurred())
 	Expect(ok).To(Equal(expected))
 }
@@ -14,10 +14,18 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	flag "github.com/spf13/pflag"
 	"golang.org/x/sync/errgroup"
+	core "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	kubevirtv1 "kubevirt.io/api/core/v1"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal/common"
 	"kubevirt.io/ssp-operator/internal/template-validator/logger"
 	"kubevirt.io/ssp-operator/internal/template-validator/service"
 	"kubevirt.io/ssp-operator/internal/template-validator/tlsinfo"
@@ -25,6 +33,7 @@ import (
 	"kubevirt.io/ssp-operator/internal/template-validator/virtinformers"
 	validating "kubevirt.io/ssp-operator/internal/template-validator/webhooks"
 	validatorMetrics "kubevirt.io/ssp-operator/pkg/monitoring/metrics/template-validator"
+	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 const (
@@ -40,8 +49,14 @@ const (
 
 type App struct {
 	service.ServiceListen
-	certsDir    string
-	versionOnly bool
+	certsDir            string
+	versionOnly         bool
+	validationMode      string
+	annotationOverrides string
+	missingTemplates    string
+	summaryWriter       string
+
+	structuredRejectionCauses bool
 }
 
 var _ service.Service = &App{}
@@ -55,6 +70,16 @@ func (app *App) AddFlags() {
 
 	flag.StringVarP(&app.certsDir, "cert-dir", "c", "", "specify path to the directory containing TLS key and certificate - this enables TLS")
 	flag.BoolVarP(&app.versionOnly, "version", "V", false, "show version and exit")
+	flag.StringVar(&app.validationMode, "validation-mode", string(ssp.TemplateValidatorModeEnforce),
+		"default validation mode: 'Enforce' rejects VMs violating validation rules, 'Audit' only records the violations")
+	flag.StringVar(&app.annotationOverrides, "annotation-overrides", string(ssp.AnnotationOverridesAllow),
+		"handling of annotations overriding validation rules set by unauthorized users: 'Allow', 'Ignore' or 'Reject'")
+	flag.StringVar(&app.missingTemplates, "missing-templates", string(ssp.MissingTemplateWarn),
+		"handling of VMs referencing a template that does not exist: 'Warn' admits them with a warning, 'Reject' rejects them")
+	flag.StringVar(&app.summaryWriter, "validation-summary-writer", "",
+		"name of the user allowed to update only the validation summary annotation of VMs violating validation rules")
+	flag.BoolVar(&app.structuredRejectionCauses, "structured-rejection-causes", false,
+		"add a machine-readable cause for each validation rule violated by a rejected VM")
 }
 
 func (app *App) Run() {
@@ -67,11 +92,38 @@ func (app *App) Run() {
 		return
 	}
 
+	validationMode, err := validating.ParseValidationMode(app.validationMode)
+	if err != nil {
+		logger.Log.Error(err, "Invalid validation mode")
+		panic(err)
+	}
+
+	annotationOverrides, err := validating.ParseAnnotationOverridesPolicy(app.annotationOverrides)
+	if err != nil {
+		logger.Log.Error(err, "Invalid annotation overrides policy")
+		panic(err)
+	}
+
+	missingTemplates, err := validating.ParseMissingTemplatePolicy(app.missingTemplates)
+	if err != nil {
+		logger.Log.Error(err, "Invalid missing templates policy")
+		panic(err)
+	}
+
 	// We cannot use default scheme.Scheme, because it contains duplicate definitions
 	// for kubevirt resources and the client would fail with an error:
 	// "multiple group-version-kinds associated with type *v1.VirtualMachineList, refusing to guess at one"
 	apiScheme := createScheme()
 
+	clientset, err := createClientset()
+	if err != nil {
+		logger.Log.Error(err, "Error creating client")
+		panic(err)
+	}
+
+	eventBroadcaster, eventRecorder := createEventRecorder(apiScheme, clientset)
+	defer eventBroadcaster.Shutdown()
+
 	informers, err := virtinformers.NewInformers(apiScheme)
 	if err != nil {
 		logger.Log.Error(err, "Error creating informers")
@@ -81,7 +133,7 @@ func (app *App) Run() {
 	informers.Start()
 	defer informers.Stop()
 
-	if err := validatorMetrics.SetupMetrics(); err != nil {
+	if err := validatorMetrics.SetupMetrics(missingTemplatesFunc(informers)); err != nil {
 		logger.Log.Error(err, "Error setting up metrics")
 		panic(err)
 	}
@@ -95,7 +147,15 @@ func (app *App) Run() {
 	}
 
 	metricsServer := app.createMetricsServer()
-	webhookServer := app.createWebhookServer(informers)
+	webhookServer := app.createWebhookServer(informers, validating.Config{
+		Mode:                      validationMode,
+		EventRecorder:             eventRecorder,
+		AnnotationOverrides:       annotationOverrides,
+		OverrideAuthorizer:        validating.NewSubjectAccessReviewAuthorizer(clientset.AuthorizationV1().SubjectAccessReviews()),
+		MissingTemplates:          missingTemplates,
+		ValidationSummaryWriter:   app.summaryWriter,
+		StructuredRejectionCauses: app.structuredRejectionCauses,
+	})
 	if tlsInfo != nil {
 		metricsServer.TLSConfig = createTLSConfig(tlsInfo)
 		webhookServer.TLSConfig = createTLSConfig(tlsInfo)
@@ -144,9 +204,9 @@ func (app *App) createMetricsServer() *http.Server {
 	}
 }
 
-func (app *App) createWebhookServer(informers *virtinformers.Informers) *http.Server {
+func (app *App) createWebhookServer(informers *virtinformers.Informers, config validating.Config) *http.Server {
 	webhookMux := http.NewServeMux()
-	validating.NewWebhooks(informers).Register(webhookMux)
+	validating.NewWebhooks(informers, config).Register(webhookMux)
 
 	webhookMux.HandleFunc("/readyz", func(resp http.ResponseWriter, req *http.Request) {
 		if _, err := resp.Write([]byte("ok")); err != nil {
@@ -199,6 +259,43 @@ func createTLSConfig(tlsInfo *tlsinfo.TLSInfo) *tls.Config {
 	}
 }
 
+func missingTemplatesFunc(informers *virtinformers.Informers) validatorMetrics.MissingTemplatesFunc {
+	return func() []validatorMetrics.MissingTemplate {
+		missing := virtinformers.MissingTemplates(informers.VmCache(), informers.TemplateStore())
+		result := make([]validatorMetrics.MissingTemplate, 0, len(missing))
+		for key, vms := range missing {
+			namespace, name, err := cache.SplitMetaNamespaceKey(key)
+			if err != nil {
+				logger.Log.Error(err, "invalid template key", "key", key)
+				continue
+			}
+			result = append(result, validatorMetrics.MissingTemplate{
+				Name:      name,
+				Namespace: namespace,
+				Vms:       vms,
+			})
+		}
+		return result
+	}
+}
+
+func createClientset() (kubernetes.Interface, error) {
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+func createEventRecorder(scheme *runtime.Scheme, clientset kubernetes.Interface) (record.EventBroadcaster, record.EventRecorder) {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+		Interface: clientset.CoreV1().Events(""),
+	})
+	recorder := broadcaster.NewRecorder(scheme, core.EventSource{Component: common.VirtTemplateValidator})
+	return broadcaster, recorder
+}
+
 func createScheme() *runtime.Scheme {
 	sch := runtime.NewScheme()
 
@@ -208,6 +305,8 @@ func createScheme() *runtime.Scheme {
 	// Setting API version of kubevirt that we want to register
 	utilruntime.Must(os.Setenv(kubevirtv1.KubeVirtClientGoSchemeRegistrationVersionEnvVar, "v1"))
 	utilruntime.Must(kubevirtv1.AddToScheme(sch))
+	utilruntime.Must(instancetypev1beta1.AddToScheme(sch))
+	utilruntime.Must(ssp.AddToScheme(sch))
 
 	return sch
 }
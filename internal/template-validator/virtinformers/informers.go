@@ -6,40 +6,105 @@ import (
 	"time"
 
 	templatev1 "github.com/openshift/api/template/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	kubevirtv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/api/instancetype"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
 	"kubevirt.io/ssp-operator/internal/template-validator/labels"
 	"kubevirt.io/ssp-operator/internal/template-validator/logger"
 )
 
+// optionalResourceProbeInterval is the interval in which unavailable optional resources are checked.
+const optionalResourceProbeInterval = time.Minute
+
+const virtualMachineValidationPolicyResource = "virtualmachinevalidationpolicies"
+
+// optionalInformer is an informer of a resource that may not be installed in the cluster.
+// It is started when the resource becomes available.
+type optionalInformer struct {
+	informer  cache.SharedIndexInformer
+	listWatch *cache.ListWatch
+}
+
 type Informers struct {
-	templateInformer cache.SharedIndexInformer
-	vmCache          VmCache
-	vmCacheReflector *cache.Reflector
-	stopCh           chan struct{}
+	templateInformer      cache.SharedIndexInformer
+	instancetypeInformers map[string]optionalInformer
+	policyInformer        optionalInformer
+	vmCache               VmCache
+	vmCacheReflector      *cache.Reflector
+	vmMetadataClient      metadata.Getter
+	clientset             kubernetes.Interface
+	stopCh                chan struct{}
 }
 
 func (inf *Informers) Start() {
 	go inf.templateInformer.Run(inf.stopCh)
 	go inf.vmCacheReflector.Run(inf.stopCh)
 
+	hasSynced := []cache.InformerSynced{inf.templateInformer.HasSynced}
+	if inf.startOptionalInformer(virtualMachineValidationPolicyResource, inf.policyInformer) {
+		hasSynced = append(hasSynced, inf.policyInformer.informer.HasSynced)
+	}
+	for resource, instancetypeInformer := range inf.instancetypeInformers {
+		// Instancetypes are optional, VMs using them are validated without expanding them.
+		if inf.startOptionalInformer(resource, instancetypeInformer) {
+			hasSynced = append(hasSynced, instancetypeInformer.informer.HasSynced)
+		}
+	}
+
 	logger.Log.Info("started informers")
-	cache.WaitForCacheSync(
-		inf.stopCh,
-		inf.templateInformer.HasSynced,
-	)
+	cache.WaitForCacheSync(inf.stopCh, hasSynced...)
 	logger.Log.Info("synced informers")
 }
 
+// startOptionalInformer runs the informer, if the resource is available.
+// Otherwise, the informer is run when the resource becomes available. It returns true
+// if the informer was started.
+func (inf *Informers) startOptionalInformer(resource string, optional optionalInformer) bool {
+	err := probeResource(context.Background(), optional.listWatch)
+	if err != nil {
+		logger.Log.Info("resource is not available, it will be used when it is installed",
+			"resource", resource,
+			"error", err.Error())
+		go inf.runOptionalInformerWhenAvailable(resource, optional)
+		return false
+	}
+	go optional.informer.Run(inf.stopCh)
+	return true
+}
+
+// runOptionalInformerWhenAvailable runs the informer once the resource is available,
+// for example when a CRD is installed after the validator was started.
+func (inf *Informers) runOptionalInformerWhenAvailable(resource string, optional optionalInformer) {
+	ctx := wait.ContextForChannel(inf.stopCh)
+	err := wait.PollUntilContextCancel(ctx, optionalResourceProbeInterval, false, func(ctx context.Context) (bool, error) {
+		return probeResource(ctx, optional.listWatch) == nil, nil
+	})
+	if err != nil {
+		// The informers were stopped
+		return
+	}
+
+	logger.Log.Info("resource is available", "resource", resource)
+	optional.informer.Run(inf.stopCh)
+}
+
 func (inf *Informers) Stop() {
 	close(inf.stopCh)
 }
@@ -48,10 +113,58 @@ func (inf *Informers) TemplateStore() cache.Store {
 	return inf.templateInformer.GetStore()
 }
 
+// AddTemplateEventHandler adds a handler that is notified when templates change.
+func (inf *Informers) AddTemplateEventHandler(handler cache.ResourceEventHandler) error {
+	_, err := inf.templateInformer.AddEventHandler(handler)
+	return err
+}
+
+// ValidationPolicyStore returns the VirtualMachineValidationPolicy store, indexed by namespace.
+// If the resource is not available in the cluster, the returned store is empty.
+func (inf *Informers) ValidationPolicyStore() cache.Indexer {
+	return inf.policyInformer.informer.GetIndexer()
+}
+
 func (inf *Informers) VmCache() VmCache {
 	return inf.vmCache
 }
 
+// InstancetypeStore returns the store for the instancetype or preference resource.
+// If the resource is not available in the cluster, the returned store is empty.
+func (inf *Informers) InstancetypeStore(resource string) cache.KeyGetter {
+	optional, ok := inf.instancetypeInformers[resource]
+	if !ok {
+		return cache.NewStore(cache.MetaNamespaceKeyFunc)
+	}
+	return optional.informer.GetStore()
+}
+
+// VmMetadata returns the metadata of the VM, or nil if it does not exist.
+// Only templated VMs are cached, so other VMs are read from the API server.
+func (inf *Informers) VmMetadata(namespace, name string) (*metav1.PartialObjectMetadata, error) {
+	vm, err := inf.vmMetadataClient.Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return vm, nil
+}
+
+// ControllerRevision returns the ControllerRevision, or nil if it does not exist.
+// ControllerRevisions are not cached, they are only read for VMs that reference a revision.
+func (inf *Informers) ControllerRevision(namespace, name string) (*appsv1.ControllerRevision, error) {
+	revision, err := inf.clientset.AppsV1().ControllerRevisions(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
 func NewInformers(scheme *runtime.Scheme) (*Informers, error) {
 	config, err := ctrl.GetConfig()
 	if err != nil {
@@ -64,21 +177,54 @@ func NewInformers(scheme *runtime.Scheme) (*Informers, error) {
 		return nil, err
 	}
 
-	vms := NewVmCache(vmNeedsTemplate)
-	reflector, err := createVmCacheReflector(config, scheme, vms)
+	instancetypeInformers, err := createInstancetypeInformers(config, scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	policyInformer, err := createValidationPolicyInformer(config, scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	metadataClient, err := metadata.NewForConfig(config)
 	if err != nil {
+		logger.Log.Error(err, "error creating metadata client")
+		return nil, err
+	}
+	vmMetadataClient := metadataClient.Resource(kubevirtv1.GroupVersion.WithResource("virtualmachines"))
+
+	vms := NewVmCache(vmNeedsCache)
+	reflector, err := createVmCacheReflector(vmMetadataClient, vms)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		logger.Log.Error(err, "error creating clientset")
 		return nil, err
 	}
 
 	return &Informers{
-		templateInformer: informer,
-		vmCache:          vms,
-		vmCacheReflector: reflector,
-		stopCh:           make(chan struct{}, 1),
+		templateInformer:      informer,
+		instancetypeInformers: instancetypeInformers,
+		policyInformer:        policyInformer,
+		vmCache:               vms,
+		vmCacheReflector:      reflector,
+		vmMetadataClient:      vmMetadataClient,
+		clientset:             clientset,
+		stopCh:                make(chan struct{}, 1),
 	}, nil
 }
 
-func vmNeedsTemplate(vm metav1.Object) bool {
+// vmNeedsCache returns true for VMs that use the rules of a template, or that skip validation.
+// Only VMs with the template label are watched, see createVmCacheReflector.
+func vmNeedsCache(vm metav1.Object) bool {
+	if _, ok := vm.GetAnnotations()[labels.VmSkipValidationAnnotationKey]; ok {
+		return true
+	}
+
 	if _, ok := vm.GetAnnotations()[labels.VmValidationAnnotationKey]; ok {
 		return false
 	}
@@ -106,15 +252,68 @@ func createTemplateInformer(restConfig *rest.Config, scheme *runtime.Scheme) (ca
 	return cache.NewSharedIndexInformer(lw, &templatev1.Template{}, resync, cache.Indexers{}), nil
 }
 
-func createVmCacheReflector(restConfig *rest.Config, scheme *runtime.Scheme, store cache.Store) (*cache.Reflector, error) {
-	restClient, err := restClientForObject(&kubevirtv1.VirtualMachine{}, restConfig, scheme)
+// createValidationPolicyInformer creates the informer for VirtualMachineValidationPolicies.
+// The resource is probed when the informer is started, see Informers.Start.
+func createValidationPolicyInformer(restConfig *rest.Config, scheme *runtime.Scheme) (optionalInformer, error) {
+	restClient, err := restClientForObject(&ssp.VirtualMachineValidationPolicy{}, restConfig, scheme)
 	if err != nil {
-		return nil, err
+		return optionalInformer{}, err
 	}
 
-	lw := cache.NewListWatchFromClient(restClient, "virtualmachines", k8sv1.NamespaceAll, fields.Everything())
+	lw := cache.NewListWatchFromClient(restClient, virtualMachineValidationPolicyResource, k8sv1.NamespaceAll, fields.Everything())
+	indexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+	return optionalInformer{
+		informer:  cache.NewSharedIndexInformer(lw, &ssp.VirtualMachineValidationPolicy{}, resyncPeriod(12*time.Hour), indexers),
+		listWatch: lw,
+	}, nil
+}
 
-	_, err = lw.ListWithContext(context.Background(), metav1.ListOptions{Limit: 1})
+// createInstancetypeInformers creates informers for all instancetype and preference resources.
+// The resources are probed when the informers are started, see Informers.Start.
+func createInstancetypeInformers(restConfig *rest.Config, scheme *runtime.Scheme) (map[string]optionalInformer, error) {
+	objects := map[string]runtime.Object{
+		instancetype.PluralResourceName:                  &instancetypev1beta1.VirtualMachineInstancetype{},
+		instancetype.ClusterPluralResourceName:           &instancetypev1beta1.VirtualMachineClusterInstancetype{},
+		instancetype.PluralPreferenceResourceName:        &instancetypev1beta1.VirtualMachinePreference{},
+		instancetype.ClusterPluralPreferenceResourceName: &instancetypev1beta1.VirtualMachineClusterPreference{},
+	}
+
+	informers := make(map[string]optionalInformer, len(objects))
+	for resource, obj := range objects {
+		restClient, err := restClientForObject(obj, restConfig, scheme)
+		if err != nil {
+			return nil, err
+		}
+
+		lw := cache.NewListWatchFromClient(restClient, resource, k8sv1.NamespaceAll, fields.Everything())
+		informers[resource] = optionalInformer{
+			informer:  cache.NewSharedIndexInformer(lw, obj, resyncPeriod(12*time.Hour), cache.Indexers{}),
+			listWatch: lw,
+		}
+	}
+	return informers, nil
+}
+
+// createVmCacheReflector watches only the metadata of VMs that reference a template by the label,
+// so the full VM objects are not transferred and decoded by each replica of the validator.
+func createVmCacheReflector(vmMetadataClient metadata.Getter, store cache.Store) (*cache.Reflector, error) {
+	vmClient := vmMetadataClient.Namespace(k8sv1.NamespaceAll)
+	setLabelSelector := func(options *metav1.ListOptions) {
+		options.LabelSelector = labels.AnnotationTemplateNameKey
+	}
+
+	lw := &cache.ListWatch{
+		ListWithContextFunc: func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+			setLabelSelector(&options)
+			return vmClient.List(ctx, options)
+		},
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			setLabelSelector(&options)
+			return vmClient.Watch(ctx, options)
+		},
+	}
+
+	_, err := lw.ListWithContext(context.Background(), metav1.ListOptions{Limit: 1})
 	if err != nil {
 		logger.Log.Error(err, "error probing the virtual machine resource")
 		return nil, err
@@ -122,12 +321,17 @@ func createVmCacheReflector(restConfig *rest.Config, scheme *runtime.Scheme, sto
 
 	return cache.NewReflector(
 		lw,
-		&kubevirtv1.VirtualMachine{},
+		&metav1.PartialObjectMetadata{},
 		store,
 		resyncPeriod(12*time.Hour),
 	), nil
 }
 
+func probeResource(ctx context.Context, lw *cache.ListWatch) error {
+	_, err := lw.ListWithContext(ctx, metav1.ListOptions{Limit: 1})
+	return err
+}
+
 func restClientForObject(obj runtime.Object, restConfig *rest.Config, scheme *runtime.Scheme) (rest.Interface, error) {
 	gvk, err := apiutil.GVKForObject(obj, scheme)
 	if err != nil {
@@ -0,0 +1,30 @@
+package virtinformers
+
+import (
+	"k8s.io/client-go/tools/cache"
+
+	"kubevirt.io/ssp-operator/internal/template-validator/logger"
+)
+
+// MissingTemplates returns the keys of templates that are referenced by VMs in the cache,
+// but do not exist, mapped to the number of VMs referencing them.
+// VMs that skip validation are not counted.
+func MissingTemplates(vmCache cache.Store, templateGetter cache.KeyGetter) map[string]int {
+	missing := map[string]int{}
+	for _, obj := range vmCache.List() {
+		value, ok := obj.(VmCacheValue)
+		if !ok || value.SkipValidation || value.Template == "" {
+			continue
+		}
+
+		_, exists, err := templateGetter.GetByKey(value.Template)
+		if err != nil {
+			logger.Log.Error(err, "failed to get template from cache", "template", value.Template)
+			continue
+		}
+		if !exists {
+			missing[value.Template]++
+		}
+	}
+	return missing
+}
@@ -21,18 +21,30 @@ type VmCache interface {
 }
 
 type VmCacheValue struct {
-	Vm       string
-	Template string
+	Vm string
+	// Template is the key of the template whose rules are used for the VM.
+	// It is empty if the VM does not reference a template, or if it defines its own rules.
+	Template       string
+	SkipValidation bool
 }
 
 func newVmCacheValue(obj metav1.Object) *VmCacheValue {
-	templateKeys := labels.GetTemplateKeys(obj)
+	_, skipValidation := obj.GetAnnotations()[labels.VmSkipValidationAnnotationKey]
 	return &VmCacheValue{
-		Vm:       vmCacheKey(obj),
-		Template: templateKeys.Get().String(),
+		Vm:             vmCacheKey(obj),
+		Template:       vmTemplate(obj),
+		SkipValidation: skipValidation,
 	}
 }
 
+func vmTemplate(obj metav1.Object) string {
+	if _, ok := obj.GetAnnotations()[labels.VmValidationAnnotationKey]; ok {
+		return ""
+	}
+	templateKeys := labels.GetTemplateKeys(obj)
+	return templateKeys.Get().String()
+}
+
 func vmCacheKey(obj metav1.Object) string {
 	return types.NamespacedName{
 		Name:      obj.GetName(),
@@ -45,6 +57,10 @@ type NameSet = map[string]struct{}
 type templateMap map[string]NameSet
 
 func (t templateMap) Add(template, vm string) {
+	if template == "" {
+		return
+	}
+
 	vmNameSet, exists := t[template]
 	if !exists {
 		vmNameSet = NameSet{}
@@ -223,6 +239,10 @@ func (v *vmCache) Replace(list []interface{}, _ string) error {
 			return err
 		}
 
+		if !v.filter(metaObj) {
+			continue
+		}
+
 		key := vmCacheKey(metaObj)
 		val := newVmCacheValue(metaObj)
 
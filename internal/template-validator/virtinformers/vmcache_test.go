@@ -9,6 +9,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
 
 	"kubevirt.io/ssp-operator/internal/template-validator/labels"
 )
@@ -49,6 +50,50 @@ var _ = Describe("VM Cache", func() {
 			Expect(cacheVal.Template).To(Equal(templateKeys.Get().String()))
 		})
 
+		It("should store skip validation annotation", func() {
+			vm := newObject("test-vm", "test-template")
+			vm.SetAnnotations(map[string]string{
+				labels.VmSkipValidationAnnotationKey: "",
+			})
+			Expect(vmCache.Add(vm)).To(Succeed())
+
+			cacheObj, exists, err := vmCache.Get(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue(), "Expected to find cache value")
+			Expect(cacheObj.(VmCacheValue).SkipValidation).To(BeTrue())
+		})
+
+		It("should not store template of VM with own rules", func() {
+			vm := newObject("test-vm", "test-template")
+			vm.SetAnnotations(map[string]string{
+				labels.VmValidationAnnotationKey: "[]",
+			})
+			Expect(vmCache.Add(vm)).To(Succeed())
+
+			cacheObj, exists, err := vmCache.Get(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue(), "Expected to find cache value")
+			Expect(cacheObj.(VmCacheValue).Template).To(BeEmpty())
+			Expect(vmCache.GetVmsForTemplate(testTemplateNamespace + "/test-template")).To(BeEmpty())
+		})
+
+		It("should add VM metadata from metadata-only watch", func() {
+			vm := &metav1.PartialObjectMetadata{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "kubevirt.io/v1",
+					Kind:       "VirtualMachine",
+				},
+				ObjectMeta: *newObject("test-vm", "test-template").(*metav1.ObjectMeta),
+			}
+			Expect(vmCache.Replace([]interface{}{vm}, "")).To(Succeed())
+
+			cacheObj, exists, err := vmCache.GetByKey(testVmNamespace + "/test-vm")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue(), "Expected to find cache value")
+			Expect(cacheObj.(VmCacheValue).Template).To(Equal(testTemplateNamespace + "/test-template"))
+			Expect(vmCache.GetVmsForTemplate(testTemplateNamespace + "/test-template")).To(ConsistOf(testVmNamespace + "/test-vm"))
+		})
+
 		It("should not add value if fails filter", func() {
 			filterFunc = func(_ metav1.Object) bool {
 				return false
@@ -62,6 +107,20 @@ var _ = Describe("VM Cache", func() {
 			Expect(exists).To(BeFalse(), "Expected vm to not exist")
 		})
 
+		It("should not replace with value if fails filter", func() {
+			filterFunc = func(_ metav1.Object) bool {
+				return false
+			}
+
+			vm := newObject("test-vm", "test-template")
+			Expect(vmCache.Replace([]interface{}{vm}, "")).To(Succeed())
+
+			_, exists, err := vmCache.Get(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeFalse(), "Expected vm to not exist")
+			Expect(vmCache.HasSynced()).To(BeTrue())
+		})
+
 		It("should update existing value", func() {
 			vm := newObject("test-vm", "test-template")
 			Expect(vmCache.Add(vm)).To(Succeed())
@@ -234,6 +293,43 @@ func newObject(name, template string) metav1.Object {
 	}
 }
 
+var _ = Describe("Missing templates", func() {
+	It("should count VMs referencing missing templates", func() {
+		vmCache := NewVmCache(func(_ metav1.Object) bool { return true })
+		templateStore := cache.NewStore(cache.MetaNamespaceKeyFunc)
+
+		Expect(templateStore.Add(&metav1.PartialObjectMetadata{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "existing-template",
+				Namespace: testTemplateNamespace,
+			},
+		})).To(Succeed())
+
+		addVm := func(name, template string, annotations map[string]string) {
+			Expect(vmCache.Add(&metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testVmNamespace,
+				Labels: map[string]string{
+					labels.AnnotationTemplateNameKey:      template,
+					labels.AnnotationTemplateNamespaceKey: testTemplateNamespace,
+				},
+				Annotations: annotations,
+			})).To(Succeed())
+		}
+
+		addVm("vm-1", "existing-template", nil)
+		addVm("vm-2", "missing-template", nil)
+		addVm("vm-3", "missing-template", nil)
+		addVm("vm-4", "other-missing-template", map[string]string{
+			labels.VmSkipValidationAnnotationKey: "",
+		})
+
+		Expect(MissingTemplates(vmCache, templateStore)).To(Equal(map[string]int{
+			testTemplateNamespace + "/missing-template": 2,
+		}))
+	})
+})
+
 func TestInformers(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Informers Suite")
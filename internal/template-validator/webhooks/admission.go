@@ -11,10 +11,16 @@ import (
 )
 
 func ValidateVm(rules []validation.Rule, vm *kubevirtv1.VirtualMachine) []metav1.StatusCause {
+	return EvaluateVm(rules, vm).ToStatusCauses()
+}
+
+// EvaluateVm sets default values on the VM and evaluates the rules on it.
+// It is used by the webhook and by the offline validation command.
+func EvaluateVm(rules []validation.Rule, vm *kubevirtv1.VirtualMachine) *validation.Result {
 	if len(rules) == 0 {
 		// no rules! everything is permitted, so let's bail out quickly
 		logger.Log.V(8).Info("no admission rules", "vm", vm.Name)
-		return nil
+		return &validation.Result{}
 	}
 
 	setDefaultValues(vm)
@@ -27,7 +33,7 @@ func ValidateVm(rules []validation.Rule, vm *kubevirtv1.VirtualMachine) []metav1
 		"summary", buf.String(),
 		"succeeded", res.Succeeded())
 
-	return res.ToStatusCauses()
+	return res
 }
 
 func setDefaultValues(vm *kubevirtv1.VirtualMachine) {
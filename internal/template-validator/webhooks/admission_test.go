@@ -6,9 +6,12 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	templatev1 "github.com/openshift/api/template/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	k6tv1 "kubevirt.io/api/core/v1"
 
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
 	"kubevirt.io/ssp-operator/internal/template-validator/labels"
 	"kubevirt.io/ssp-operator/internal/template-validator/validation"
 	"kubevirt.io/ssp-operator/internal/template-validator/validation/path"
@@ -105,7 +108,7 @@ var _ = Describe("Admission", func() {
 				Spec: k6tv1.VirtualMachineSpec{},
 			}
 
-			vmRules, err := getValidationRulesForVM(vm, nil)
+			_, vmRules, err := getValidationRulesForVM(vm, nil, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(vmRules).To(HaveLen(1))
@@ -115,6 +118,174 @@ var _ = Describe("Admission", func() {
 	})
 })
 
+var _ = Describe("Audit mode", func() {
+	const (
+		templateName      = "test-template"
+		templateNamespace = "test-template-ns"
+	)
+
+	var (
+		eventRecorder *record.FakeRecorder
+		hooks         *webhooks
+		tmpl          *templatev1.Template
+	)
+
+	BeforeEach(func() {
+		eventRecorder = record.NewFakeRecorder(10)
+		hooks = NewWebhooks(nil, Config{
+			EventRecorder: eventRecorder,
+		}).(*webhooks)
+
+		tmpl = &templatev1.Template{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      templateName,
+				Namespace: templateNamespace,
+			},
+		}
+	})
+
+	DescribeTable("should parse validation mode", func(value string, expected ssp.TemplateValidatorMode) {
+		mode, err := ParseValidationMode(value)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mode).To(Equal(expected))
+	},
+		Entry("Enforce", "Enforce", ssp.TemplateValidatorModeEnforce),
+		Entry("enforce", "enforce", ssp.TemplateValidatorModeEnforce),
+		Entry("Audit", "Audit", ssp.TemplateValidatorModeAudit),
+		Entry("AUDIT", "AUDIT", ssp.TemplateValidatorModeAudit),
+	)
+
+	It("should fail to parse unknown validation mode", func() {
+		_, err := ParseValidationMode("warn")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should use Enforce mode by default", func() {
+		Expect(hooks.validationModeFor(nil)).To(Equal(ssp.TemplateValidatorModeEnforce))
+		Expect(hooks.validationModeFor(tmpl)).To(Equal(ssp.TemplateValidatorModeEnforce))
+	})
+
+	It("should use configured mode if template does not override it", func() {
+		hooks.config.Mode = ssp.TemplateValidatorModeAudit
+		Expect(hooks.validationModeFor(nil)).To(Equal(ssp.TemplateValidatorModeAudit))
+		Expect(hooks.validationModeFor(tmpl)).To(Equal(ssp.TemplateValidatorModeAudit))
+	})
+
+	It("should use mode from template annotation", func() {
+		tmpl.Annotations = map[string]string{
+			labels.AnnotationValidationModeKey: "audit",
+		}
+		Expect(hooks.validationModeFor(tmpl)).To(Equal(ssp.TemplateValidatorModeAudit))
+
+		hooks.config.Mode = ssp.TemplateValidatorModeAudit
+		tmpl.Annotations[labels.AnnotationValidationModeKey] = "Enforce"
+		Expect(hooks.validationModeFor(tmpl)).To(Equal(ssp.TemplateValidatorModeEnforce))
+	})
+
+	It("should ignore invalid mode in template annotation", func() {
+		hooks.config.Mode = ssp.TemplateValidatorModeAudit
+		tmpl.Annotations = map[string]string{
+			labels.AnnotationValidationModeKey: "invalid",
+		}
+		Expect(hooks.validationModeFor(tmpl)).To(Equal(ssp.TemplateValidatorModeAudit))
+	})
+
+	It("should admit VM and record violations", func() {
+		const ruleName = "cores-rule"
+		rules := []validation.Rule{{
+			Name:    ruleName,
+			Path:    *path.NewOrPanic("jsonpath::.spec.domain.cpu.cores"),
+			Rule:    "integer",
+			Message: "too many cores",
+			Max:     &path.IntOrPath{Int: 2},
+		}}
+
+		vm := &k6tv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vm",
+				Namespace: "test-vm-ns",
+			},
+			Spec: k6tv1.VirtualMachineSpec{
+				Template: &k6tv1.VirtualMachineInstanceTemplateSpec{
+					Spec: k6tv1.VirtualMachineInstanceSpec{
+						Domain: k6tv1.DomainSpec{
+							CPU: &k6tv1.CPU{Cores: 4},
+						},
+					},
+				},
+			},
+		}
+
+		result := EvaluateVm(rules, vm)
+		Expect(result.Succeeded()).To(BeFalse())
+		Expect(result.Violations()).To(HaveLen(1))
+
+		response := hooks.auditVm(vm, tmpl, result.Violations())
+		Expect(response.Allowed).To(BeTrue())
+		Expect(response.Warnings).To(HaveLen(1))
+		Expect(response.Warnings[0]).To(ContainSubstring(ruleName))
+
+		Expect(response.AuditAnnotations).To(HaveKey(auditViolationsAnnotationKey))
+		var violations []auditViolation
+		Expect(json.Unmarshal([]byte(response.AuditAnnotations[auditViolationsAnnotationKey]), &violations)).To(Succeed())
+		Expect(violations).To(HaveLen(1))
+		Expect(violations[0].Rule).To(Equal(ruleName))
+		Expect(violations[0].Message).To(ContainSubstring("too many cores"))
+
+		Expect(eventRecorder.Events).To(Receive(And(
+			ContainSubstring(ValidationRuleViolatedReason),
+			ContainSubstring(ruleName),
+		)))
+	})
+
+	It("should reject VM with a cause for each violation", func() {
+		rules := []validation.Rule{{
+			Name:    "cores-rule",
+			Path:    *path.NewOrPanic("jsonpath::.spec.domain.cpu.cores"),
+			Rule:    "integer",
+			Message: "too many cores",
+			Max:     &path.IntOrPath{Int: 2},
+		}}
+
+		vm := &k6tv1.VirtualMachine{
+			Spec: k6tv1.VirtualMachineSpec{
+				Template: &k6tv1.VirtualMachineInstanceTemplateSpec{
+					Spec: k6tv1.VirtualMachineInstanceSpec{
+						Domain: k6tv1.DomainSpec{
+							CPU: &k6tv1.CPU{Cores: 4},
+						},
+					},
+				},
+			},
+		}
+
+		response := hooks.validateVm(vm, tmpl, rules)
+		Expect(response.Allowed).To(BeFalse())
+		Expect(response.Result.Message).To(Equal("too many cores: value 4 is higher than maximum [2]"))
+		Expect(response.Result.Details.Causes).To(Equal([]metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "spec.template.spec.domain.cpu.cores",
+			Message: "too many cores: value 4 is higher than maximum [2]",
+		}}))
+
+		hooks.config.StructuredRejectionCauses = true
+		response = hooks.validateVm(vm, tmpl, rules)
+		Expect(response.Allowed).To(BeFalse())
+		Expect(response.Result.Details.Causes).To(HaveLen(2))
+		Expect(response.Result.Details.Causes[0].Type).To(Equal(metav1.CauseTypeFieldValueInvalid))
+
+		cause := response.Result.Details.Causes[1]
+		Expect(cause.Type).To(Equal(validation.CauseTypeRuleViolation))
+		Expect(cause.Field).To(Equal("spec.template.spec.domain.cpu.cores"))
+
+		violation := &validation.RuleViolation{}
+		Expect(json.Unmarshal([]byte(cause.Message), violation)).To(Succeed())
+		Expect(violation.Rule).To(Equal("cores-rule"))
+		Expect(violation.Values).To(Equal([]string{"4"}))
+		Expect(violation.Max).To(HaveValue(Equal(int64(2))))
+	})
+})
+
 func TestValidating(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Validating Suite")
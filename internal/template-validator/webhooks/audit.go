@@ -0,0 +1,98 @@
+package validating
+
+import (
+	"encoding/json"
+	"fmt"
+
+	templatev1 "github.com/openshift/api/template/v1"
+	admissionv1 "k8s.io/api/admission/v1"
+	core "k8s.io/api/core/v1"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
+	"kubevirt.io/ssp-operator/internal/template-validator/logger"
+	"kubevirt.io/ssp-operator/internal/template-validator/validation"
+	"kubevirt.io/ssp-operator/pkg/monitoring/metrics/template-validator"
+)
+
+const (
+	ValidationRuleViolatedReason = "ValidationRuleViolated"
+
+	// The API server prefixes this key with the webhook name.
+	auditViolationsAnnotationKey = "validation-violations"
+)
+
+// ParseValidationMode converts a case-insensitive string to a TemplateValidatorMode.
+func ParseValidationMode(value string) (ssp.TemplateValidatorMode, error) {
+	return ParseEnum("validation mode", value, ssp.TemplateValidatorModeEnforce, ssp.TemplateValidatorModeAudit)
+}
+
+func (w *webhooks) validationModeFor(tmpl *templatev1.Template) ssp.TemplateValidatorMode {
+	if tmpl == nil {
+		return w.config.Mode
+	}
+
+	value, exists := tmpl.Annotations[labels.AnnotationValidationModeKey]
+	if !exists {
+		return w.config.Mode
+	}
+
+	mode, err := ParseValidationMode(value)
+	if err != nil {
+		logger.Log.Error(err, "invalid validation mode annotation on template, using the default mode",
+			"template", tmpl.Namespace+"/"+tmpl.Name,
+			"mode", w.config.Mode)
+		return w.config.Mode
+	}
+	return mode
+}
+
+type auditViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// auditVm records the violations of a VM that is admitted in audit mode.
+func (w *webhooks) auditVm(vm *kubevirtv1.VirtualMachine, tmpl *templatev1.Template, reports []validation.ValidationReport) *admissionv1.AdmissionResponse {
+	templateName, templateNamespace := templateNameAndNamespace(tmpl)
+
+	violations := make([]auditViolation, 0, len(reports))
+	warnings := make([]string, 0, len(reports))
+	for _, report := range reports {
+		message := report.Message
+		if report.Error != nil {
+			message = report.Error.Error()
+		}
+		violation := auditViolation{
+			Rule:    report.Ref.Name,
+			Message: fmt.Sprintf("%s: %s", report.Ref.Message, message),
+		}
+		violations = append(violations, violation)
+		warnings = append(warnings, fmt.Sprintf("validation rule %q is violated (audit mode): %s", violation.Rule, violation.Message))
+
+		metrics.IncTemplateValidatorAuditViolations(templateName, templateNamespace, violation.Rule)
+		if w.config.EventRecorder != nil {
+			w.config.EventRecorder.Eventf(vm, core.EventTypeWarning, ValidationRuleViolatedReason,
+				"Validation rule %q is violated (audit mode): %s", violation.Rule, violation.Message)
+		}
+	}
+
+	logger.Log.V(2).Info("admitted VM violating validation rules in audit mode",
+		"vm", vm.Namespace+"/"+vm.Name,
+		"template", templateNamespace+"/"+templateName,
+		"violations", len(violations))
+
+	response := ToAdmissionResponseOK()
+	response.Warnings = warnings
+
+	violationsJson, err := json.Marshal(violations)
+	if err != nil {
+		logger.Log.Error(err, "could not marshal audit violations to json")
+		return response
+	}
+	response.AuditAnnotations = map[string]string{
+		auditViolationsAnnotationKey: string(violationsJson),
+	}
+	return response
+}
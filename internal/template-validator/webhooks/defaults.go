@@ -0,0 +1,279 @@
+package validating
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	templatev1 "github.com/openshift/api/template/v1"
+	"gomodules.xyz/jsonpatch/v2"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
+	"kubevirt.io/ssp-operator/internal/template-validator/logger"
+	"kubevirt.io/ssp-operator/internal/template-validator/validation"
+)
+
+// simpleFieldPath matches rule paths consisting only of field names, like ".spec.domain.cpu.cores".
+// Only such paths can be used to set default values.
+var simpleFieldPath = regexp.MustCompile(`^(\.[a-zA-Z0-9_-]+)+$`)
+
+// mutateVm fills in values missing in a created VM, if its parent template has
+// the AnnotationApplyDefaultsKey annotation. The values are taken from the VM
+// object in the template, and from the validation rules of the template.
+func (w *webhooks) mutateVm(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	if ar.Request.Operation != admissionv1.Create {
+		return ToAdmissionResponseOK()
+	}
+
+	vm, err := GetAdmissionReviewVM(ar)
+	if err != nil {
+		return ToAdmissionResponseError(err)
+	}
+
+	patch, err := getVmDefaultsPatch(vm, ar.Request.Object.Raw, w.informers.TemplateStore(), w.informers)
+	if err != nil {
+		return ToAdmissionResponseError(err)
+	}
+	if patch == nil {
+		return ToAdmissionResponseOK()
+	}
+	return ToAdmissionResponsePatch(patch)
+}
+
+// getVmDefaultsPatch returns a JSON patch setting the default values of the VM,
+// or nil if the VM does not need to be modified. The defaults are applied to rawVm,
+// the VM as it was sent by the user, so the patch adds only the defaulted fields
+// and does not drop fields unknown to this version of the KubeVirt API.
+// Fields provided by the instancetype or the preference of the VM are not set.
+func getVmDefaultsPatch(vm *kubevirtv1.VirtualMachine, rawVm []byte, templateGetter cache.KeyGetter, instancetypeStores InstancetypeStores) ([]byte, error) {
+	if vm.Spec.Template == nil || !vmUsesTemplateRules(vm) {
+		return nil, nil
+	}
+
+	tmpl, err := getParentTemplateForVM(vm, templateGetter)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl == nil || tmpl.Annotations[labels.AnnotationApplyDefaultsKey] != "true" {
+		return nil, nil
+	}
+
+	rules, err := getValidationRulesFromTemplate(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := decodeJsonObject(rawVm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode VM: %w", err)
+	}
+	vmiTemplate, ok := nestedObject(obj, "spec", "template")
+	if !ok {
+		return nil, nil
+	}
+
+	providedFields, err := getInstancetypeProvidedFields(vm, instancetypeStores)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateVmiTemplate := getTemplateVmiTemplate(tmpl); templateVmiTemplate != nil {
+		applyTemplateDefaults(vmiTemplate, templateVmiTemplate, providedFields)
+	}
+	applyRuleDefaults(vm, vmiTemplate, rules, providedFields)
+
+	mutatedVm, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	operations, err := jsonpatch.CreatePatch(rawVm, mutatedVm)
+	if err != nil {
+		return nil, err
+	}
+	if len(operations) == 0 {
+		return nil, nil
+	}
+
+	logger.Log.V(2).Info("applied template defaults",
+		"vm", vm.Namespace+"/"+vm.Name,
+		"template", tmpl.Namespace+"/"+tmpl.Name)
+
+	return json.Marshal(operations)
+}
+
+// getTemplateVmiTemplate returns the VMI template of the VM object of the template, or nil
+// if the template does not contain a VM that can be decoded. Parameters are not processed,
+// so values containing parameters cannot be used as defaults.
+func getTemplateVmiTemplate(tmpl *templatev1.Template) map[string]interface{} {
+	for _, obj := range tmpl.Objects {
+		typeMeta := &metav1.TypeMeta{}
+		if err := json.Unmarshal(obj.Raw, typeMeta); err != nil || typeMeta.Kind != kubevirtv1.VirtualMachineGroupVersionKind.Kind {
+			continue
+		}
+
+		templateVm, err := decodeJsonObject(obj.Raw)
+		if err != nil {
+			logger.Log.V(4).Info("cannot decode VM in template",
+				"template", tmpl.Namespace+"/"+tmpl.Name,
+				"error", err.Error())
+			return nil
+		}
+		vmiTemplate, _ := nestedObject(templateVm, "spec", "template")
+		return vmiTemplate
+	}
+	return nil
+}
+
+// getInstancetypeProvidedFields returns the fields of the VMI template that are provided
+// by the instancetype and the preference of the VM. KubeVirt rejects VMs that set CPU or memory
+// together with an instancetype, and values set in the VM take precedence over the preference.
+// If the preference cannot be found, all fields that a preference can provide are returned.
+func getInstancetypeProvidedFields(vm *kubevirtv1.VirtualMachine, stores InstancetypeStores) ([][]string, error) {
+	var providedFields [][]string
+	if vm.Spec.Instancetype != nil {
+		providedFields = append(providedFields,
+			[]string{"spec", "domain", "cpu"},
+			[]string{"spec", "domain", "memory"},
+			[]string{"spec", "domain", "resources", "requests", "cpu"},
+			[]string{"spec", "domain", "resources", "requests", "memory"},
+		)
+	}
+	if vm.Spec.Preference == nil {
+		return providedFields, nil
+	}
+
+	preferenceSpec, err := getPreferenceSpec(vm, stores)
+	if err != nil {
+		return nil, err
+	}
+
+	preferenceFields := []struct {
+		provided bool
+		fields   []string
+	}{
+		{preferenceSpec == nil || preferenceSpec.Clock != nil, []string{"spec", "domain", "clock"}},
+		{preferenceSpec == nil || preferenceSpec.CPU != nil, []string{"spec", "domain", "cpu"}},
+		{preferenceSpec == nil || preferenceSpec.Devices != nil, []string{"spec", "domain", "devices"}},
+		{preferenceSpec == nil || preferenceSpec.Features != nil, []string{"spec", "domain", "features"}},
+		{preferenceSpec == nil || preferenceSpec.Firmware != nil, []string{"spec", "domain", "firmware"}},
+		{preferenceSpec == nil || preferenceSpec.Machine != nil, []string{"spec", "domain", "machine"}},
+		{preferenceSpec == nil || preferenceSpec.PreferredSubdomain != nil, []string{"spec", "subdomain"}},
+		{preferenceSpec == nil || preferenceSpec.PreferredTerminationGracePeriodSeconds != nil, []string{"spec", "terminationGracePeriodSeconds"}},
+	}
+	for _, preferenceField := range preferenceFields {
+		if preferenceField.provided {
+			providedFields = append(providedFields, preferenceField.fields)
+		}
+	}
+	return providedFields, nil
+}
+
+// isProvidedField returns true if the field is one of the provided fields, or is nested in one.
+func isProvidedField(fields []string, providedFields [][]string) bool {
+	for _, provided := range providedFields {
+		if len(fields) >= len(provided) && slices.Equal(fields[:len(provided)], provided) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTemplateDefaults copies the memory, CPU, machine type and firmware
+// from the VMI template of the template VM, if they are not set in the VM
+// and not provided by its instancetype or preference.
+func applyTemplateDefaults(vmiTemplate map[string]interface{}, templateVmiTemplate map[string]interface{}, providedFields [][]string) {
+	copyField := func(fields ...string) {
+		path := append([]string{"spec", "domain"}, fields...)
+		if isProvidedField(path, providedFields) {
+			return
+		}
+		value, found, err := unstructured.NestedFieldNoCopy(templateVmiTemplate, path...)
+		if !found || err != nil || value == nil || hasField(vmiTemplate, path...) {
+			return
+		}
+		if err := unstructured.SetNestedField(vmiTemplate, value, path...); err != nil {
+			logger.Log.V(4).Info("cannot set default value from template", "field", strings.Join(path, "."), "error", err.Error())
+		}
+	}
+
+	// Memory can be defined either as guest memory, or as memory request
+	if !hasField(vmiTemplate, "spec", "domain", "memory") && !hasField(vmiTemplate, "spec", "domain", "resources", "requests", "memory") {
+		copyField("memory")
+		copyField("resources", "requests", "memory")
+	}
+	copyField("cpu")
+	copyField("machine")
+	copyField("firmware")
+}
+
+// applyRuleDefaults sets the fields checked by integer and enum rules, if they are not set in the VM.
+// Integer rules set the field to their minimum, and enum rules to their first value.
+// Rules using paths as arguments, paths that do not point to a single field, or paths
+// of fields provided by the instancetype or preference of the VM are ignored.
+func applyRuleDefaults(vm *kubevirtv1.VirtualMachine, vmiTemplate map[string]interface{}, rules []validation.Rule, providedFields [][]string) {
+	for i := range rules {
+		rule := &rules[i]
+		value, ok := ruleDefaultValue(rule)
+		if !ok || !simpleFieldPath.MatchString(rule.Path.Expr()) || !rule.IsAppliableOn(vm) {
+			continue
+		}
+
+		fields := strings.Split(strings.TrimPrefix(rule.Path.Expr(), "."), ".")
+		if hasField(vmiTemplate, fields...) || isProvidedField(fields, providedFields) {
+			continue
+		}
+		if err := unstructured.SetNestedField(vmiTemplate, value, fields...); err != nil {
+			logger.Log.V(4).Info("cannot set default value of rule", "rule", rule.Name, "error", err.Error())
+			continue
+		}
+	}
+}
+
+// decodeJsonObject decodes a JSON object, keeping numbers as json.Number,
+// so values that are not modified are encoded again without any change.
+func decodeJsonObject(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	obj := map[string]interface{}{}
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func nestedObject(obj map[string]interface{}, fields ...string) (map[string]interface{}, bool) {
+	value, found, err := unstructured.NestedFieldNoCopy(obj, fields...)
+	if !found || err != nil {
+		return nil, false
+	}
+	result, ok := value.(map[string]interface{})
+	return result, ok
+}
+
+// hasField returns true if the field is set to a value other than null.
+func hasField(obj map[string]interface{}, fields ...string) bool {
+	value, found, err := unstructured.NestedFieldNoCopy(obj, fields...)
+	return found && err == nil && value != nil
+}
+
+func ruleDefaultValue(rule *validation.Rule) (interface{}, bool) {
+	switch rule.Rule {
+	case validation.IntegerRule:
+		if rule.Min != nil && rule.Min.IsInt() {
+			return rule.Min.Int, true
+		}
+	case validation.EnumRule:
+		if len(rule.Values) > 0 && rule.Values[0].IsString() {
+			return rule.Values[0].Str, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,283 @@
+package validating
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	templatev1 "github.com/openshift/api/template/v1"
+	"gomodules.xyz/jsonpatch/v2"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	k6tv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/api/instancetype"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
+)
+
+var _ = Describe("Template defaults", func() {
+	const (
+		namespace    = "test-ns"
+		templateName = "test-template"
+	)
+
+	var (
+		templateStore      cache.Store
+		instancetypeStores fakeInstancetypeStores
+		tmpl               *templatev1.Template
+		vm                 *k6tv1.VirtualMachine
+	)
+
+	getRawVm := func() map[string]interface{} {
+		vmJson, err := json.Marshal(vm)
+		Expect(err).ToNot(HaveOccurred())
+		rawVm := map[string]interface{}{}
+		Expect(json.Unmarshal(vmJson, &rawVm)).To(Succeed())
+		return rawVm
+	}
+
+	getPatch := func(rawVm map[string]interface{}) []byte {
+		rawVmJson, err := json.Marshal(rawVm)
+		Expect(err).ToNot(HaveOccurred())
+		patch, err := getVmDefaultsPatch(vm, rawVmJson, templateStore, instancetypeStores)
+		Expect(err).ToNot(HaveOccurred())
+		return patch
+	}
+
+	// applyPatch applies the patch to rawVm. Only "add" operations are expected,
+	// because only missing fields are set.
+	applyPatch := func(rawVm map[string]interface{}, patch []byte) {
+		var operations []jsonpatch.Operation
+		Expect(json.Unmarshal(patch, &operations)).To(Succeed())
+		Expect(operations).ToNot(BeEmpty())
+		for _, operation := range operations {
+			Expect(operation.Operation).To(Equal("add"))
+			fields := strings.Split(strings.TrimPrefix(operation.Path, "/"), "/")
+			Expect(unstructured.SetNestedField(rawVm, operation.Value, fields...)).To(Succeed())
+		}
+	}
+
+	getPatchedSpec := func(patch []byte) *k6tv1.VirtualMachineInstanceSpec {
+		rawVm := getRawVm()
+		applyPatch(rawVm, patch)
+
+		patchedVm := &k6tv1.VirtualMachine{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(rawVm, patchedVm)).To(Succeed())
+		return &patchedVm.Spec.Template.Spec
+	}
+
+	BeforeEach(func() {
+		templateVm := &k6tv1.VirtualMachine{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: k6tv1.GroupVersion.String(),
+				Kind:       "VirtualMachine",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "${NAME}",
+			},
+			Spec: k6tv1.VirtualMachineSpec{
+				Template: &k6tv1.VirtualMachineInstanceTemplateSpec{
+					Spec: k6tv1.VirtualMachineInstanceSpec{
+						Domain: k6tv1.DomainSpec{
+							Memory: &k6tv1.Memory{
+								Guest: resource.NewQuantity(2*1024*1024*1024, resource.BinarySI),
+							},
+							Machine: &k6tv1.Machine{Type: "q35"},
+							Firmware: &k6tv1.Firmware{
+								Bootloader: &k6tv1.Bootloader{EFI: &k6tv1.EFI{}},
+							},
+						},
+					},
+				},
+			},
+		}
+		templateVmJson, err := json.Marshal(templateVm)
+		Expect(err).ToNot(HaveOccurred())
+
+		tmpl = &templatev1.Template{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      templateName,
+				Namespace: namespace,
+				Annotations: map[string]string{
+					labels.AnnotationApplyDefaultsKey: "true",
+					labels.AnnotationValidationKey: `[{
+						"name": "MinCores",
+						"path": "jsonpath::.spec.domain.cpu.cores",
+						"message": "Too few cores",
+						"rule": "integer",
+						"min": 2
+					}, {
+						"name": "CpuModel",
+						"path": "jsonpath::.spec.domain.cpu.model",
+						"message": "Unsupported CPU model",
+						"rule": "enum",
+						"values": ["host-passthrough", "host-model"]
+					}, {
+						"name": "Interfaces",
+						"path": "jsonpath::.spec.domain.devices.interfaces[*].model",
+						"message": "Unsupported interface model",
+						"rule": "enum",
+						"values": ["virtio"]
+					}]`,
+				},
+			},
+			Objects: []runtime.RawExtension{{Raw: templateVmJson}},
+		}
+
+		templateStore = cache.NewStore(cache.MetaNamespaceKeyFunc)
+		Expect(templateStore.Add(tmpl)).To(Succeed())
+
+		clusterPreferences := cache.NewStore(cache.MetaNamespaceKeyFunc)
+		Expect(clusterPreferences.Add(&instancetypev1beta1.VirtualMachineClusterPreference{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-preference"},
+			Spec: instancetypev1beta1.VirtualMachinePreferenceSpec{
+				Machine: &instancetypev1beta1.MachinePreferences{PreferredMachineType: "pc"},
+			},
+		})).To(Succeed())
+		instancetypeStores = fakeInstancetypeStores{
+			stores: map[string]cache.Store{
+				instancetype.ClusterPluralPreferenceResourceName: clusterPreferences,
+			},
+		}
+
+		vm = &k6tv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vm",
+				Namespace: namespace,
+				Labels: map[string]string{
+					labels.AnnotationTemplateNameKey:      templateName,
+					labels.AnnotationTemplateNamespaceKey: namespace,
+				},
+			},
+			Spec: k6tv1.VirtualMachineSpec{
+				Template: &k6tv1.VirtualMachineInstanceTemplateSpec{},
+			},
+		}
+	})
+
+	It("should apply defaults from template VM and rules", func() {
+		patch := getPatch(getRawVm())
+		Expect(patch).ToNot(BeNil())
+
+		spec := getPatchedSpec(patch)
+		Expect(spec.Domain.Memory.Guest.Value()).To(Equal(int64(2 * 1024 * 1024 * 1024)))
+		Expect(spec.Domain.Machine.Type).To(Equal("q35"))
+		Expect(spec.Domain.Firmware.Bootloader.EFI).ToNot(BeNil())
+		Expect(spec.Domain.CPU.Cores).To(Equal(uint32(2)))
+		Expect(spec.Domain.CPU.Model).To(Equal("host-passthrough"))
+		Expect(spec.Domain.Devices.Interfaces).To(BeEmpty())
+	})
+
+	It("should not overwrite values set in the VM", func() {
+		vm.Spec.Template.Spec.Domain = k6tv1.DomainSpec{
+			Resources: k6tv1.ResourceRequirements{
+				Requests: k8sv1.ResourceList{
+					k8sv1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+			CPU:     &k6tv1.CPU{Cores: 4, Model: "host-model"},
+			Machine: &k6tv1.Machine{Type: "pc"},
+		}
+
+		patch := getPatch(getRawVm())
+
+		spec := getPatchedSpec(patch)
+		Expect(spec.Domain.Memory).To(BeNil())
+		Expect(spec.Domain.Resources.Requests.Memory().String()).To(Equal("1Gi"))
+		Expect(spec.Domain.CPU.Cores).To(Equal(uint32(4)))
+		Expect(spec.Domain.CPU.Model).To(Equal("host-model"))
+		Expect(spec.Domain.Machine.Type).To(Equal("pc"))
+		Expect(spec.Domain.Firmware.Bootloader.EFI).ToNot(BeNil())
+	})
+
+	It("should not apply CPU and memory defaults to VM with instancetype", func() {
+		vm.Spec.Instancetype = &k6tv1.InstancetypeMatcher{Name: "cluster-instancetype"}
+
+		patch := getPatch(getRawVm())
+
+		spec := getPatchedSpec(patch)
+		Expect(spec.Domain.Memory).To(BeNil())
+		Expect(spec.Domain.Resources.Requests).To(BeEmpty())
+		Expect(spec.Domain.CPU).To(BeNil())
+		Expect(spec.Domain.Machine.Type).To(Equal("q35"))
+		Expect(spec.Domain.Firmware.Bootloader.EFI).ToNot(BeNil())
+	})
+
+	It("should not apply defaults provided by preference", func() {
+		vm.Spec.Preference = &k6tv1.PreferenceMatcher{Name: "cluster-preference"}
+
+		patch := getPatch(getRawVm())
+
+		spec := getPatchedSpec(patch)
+		Expect(spec.Domain.Machine).To(BeNil())
+		Expect(spec.Domain.Memory.Guest.Value()).To(Equal(int64(2 * 1024 * 1024 * 1024)))
+		Expect(spec.Domain.CPU.Cores).To(Equal(uint32(2)))
+		Expect(spec.Domain.Firmware.Bootloader.EFI).ToNot(BeNil())
+	})
+
+	It("should not apply defaults that a missing preference could provide", func() {
+		vm.Spec.Preference = &k6tv1.PreferenceMatcher{Name: "non-existing"}
+
+		patch := getPatch(getRawVm())
+
+		spec := getPatchedSpec(patch)
+		Expect(spec.Domain.Machine).To(BeNil())
+		Expect(spec.Domain.Firmware).To(BeNil())
+		Expect(spec.Domain.CPU).To(BeNil())
+		Expect(spec.Domain.Memory.Guest.Value()).To(Equal(int64(2 * 1024 * 1024 * 1024)))
+	})
+
+	It("should keep fields unknown to the API", func() {
+		rawVm := getRawVm()
+		Expect(unstructured.SetNestedField(rawVm, "new-value", "spec", "template", "spec", "newField")).To(Succeed())
+		Expect(unstructured.SetNestedField(rawVm, "new-value", "spec", "template", "spec", "domain", "newField")).To(Succeed())
+
+		patch := getPatch(rawVm)
+
+		var operations []jsonpatch.Operation
+		Expect(json.Unmarshal(patch, &operations)).To(Succeed())
+		for _, operation := range operations {
+			Expect(operation.Path).To(HavePrefix("/spec/template/spec/domain/"))
+		}
+
+		applyPatch(rawVm, patch)
+		spec, _, err := unstructured.NestedMap(rawVm, "spec", "template", "spec")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(spec).To(HaveKeyWithValue("newField", "new-value"))
+		Expect(spec["domain"]).To(HaveKeyWithValue("newField", "new-value"))
+		Expect(spec["domain"]).To(HaveKeyWithValue("machine", map[string]interface{}{"type": "q35"}))
+	})
+
+	It("should not modify VM if template does not have the annotation", func() {
+		delete(tmpl.Annotations, labels.AnnotationApplyDefaultsKey)
+		Expect(templateStore.Update(tmpl)).To(Succeed())
+
+		Expect(getPatch(getRawVm())).To(BeNil())
+	})
+
+	It("should not modify VM that skips validation", func() {
+		vm.Annotations = map[string]string{
+			labels.VmSkipValidationAnnotationKey: "",
+		}
+
+		Expect(getPatch(getRawVm())).To(BeNil())
+	})
+
+	It("should not modify VM that has all values", func() {
+		vm.Spec.Template.Spec.Domain = k6tv1.DomainSpec{
+			Memory:   &k6tv1.Memory{Guest: resource.NewQuantity(1024*1024*1024, resource.BinarySI)},
+			CPU:      &k6tv1.CPU{Cores: 4, Model: "host-model"},
+			Machine:  &k6tv1.Machine{Type: "pc"},
+			Firmware: &k6tv1.Firmware{},
+		}
+
+		Expect(getPatch(getRawVm())).To(BeNil())
+	})
+})
@@ -23,14 +23,20 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
+	templatev1 "github.com/openshift/api/template/v1"
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	kubevirtv1 "kubevirt.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
 	common_templates "kubevirt.io/ssp-operator/internal/operands/common-templates"
 	"kubevirt.io/ssp-operator/internal/template-validator/labels"
 	"kubevirt.io/ssp-operator/internal/template-validator/logger"
+	"kubevirt.io/ssp-operator/internal/template-validator/validation"
 	"kubevirt.io/ssp-operator/internal/template-validator/virtinformers"
 	"kubevirt.io/ssp-operator/pkg/monitoring/metrics/template-validator"
 )
@@ -38,6 +44,10 @@ import (
 const (
 	VmValidatePath       string = "/virtualmachine-validate"
 	TemplateValidatePath string = "/template-validate"
+	VmiValidatePath      string = "/virtualmachineinstance-validate"
+	VmMutatePath         string = "/virtualmachine-mutate"
+
+	ValidationPolicyValidatePath string = "/virtualmachinevalidationpolicy-validate"
 )
 
 type admitFunc func(*admissionv1.AdmissionReview) *admissionv1.AdmissionResponse
@@ -46,22 +56,66 @@ type Webhooks interface {
 	Register(mux *http.ServeMux)
 }
 
+type Config struct {
+	// Mode is used for VMs whose template does not override it.
+	Mode ssp.TemplateValidatorMode
+	// EventRecorder is used to report validation rule violations in audit mode.
+	EventRecorder record.EventRecorder
+	// AnnotationOverrides defines how annotations overriding validation rules
+	// are handled, when they are set by users who are not allowed to set them.
+	AnnotationOverrides ssp.AnnotationOverridesPolicy
+	// OverrideAuthorizer checks if users are allowed to set annotations overriding validation rules.
+	OverrideAuthorizer OverrideAuthorizer
+	// MissingTemplates defines how VMs referencing a template that does not exist are handled.
+	MissingTemplates ssp.MissingTemplatePolicy
+	// ValidationSummaryWriter is the name of the user allowed to update only the validation summary
+	// annotation of VMs that violate the rules. If it is empty, such updates are validated as any other.
+	ValidationSummaryWriter string
+	// StructuredRejectionCauses adds a machine-readable cause for each rule violated by a rejected VM.
+	StructuredRejectionCauses bool
+}
+
 type webhooks struct {
 	informers *virtinformers.Informers
+	config    Config
 }
 
-func NewWebhooks(informers *virtinformers.Informers) Webhooks {
+func NewWebhooks(informers *virtinformers.Informers, config Config) Webhooks {
+	if config.Mode == "" {
+		config.Mode = ssp.TemplateValidatorModeEnforce
+	}
+	if config.AnnotationOverrides == "" {
+		config.AnnotationOverrides = ssp.AnnotationOverridesAllow
+	}
+	if config.MissingTemplates == "" {
+		config.MissingTemplates = ssp.MissingTemplateWarn
+	}
+	if informers != nil {
+		if err := informers.AddTemplateEventHandler(templateRulesInvalidator(templateRules)); err != nil {
+			logger.Log.Error(err, "failed to watch templates for changes of validation rules")
+		}
+	}
 	return &webhooks{
 		informers: informers,
+		config:    config,
 	}
 }
 
 func (w *webhooks) Register(mux *http.ServeMux) {
 	mux.HandleFunc(VmValidatePath, func(resp http.ResponseWriter, req *http.Request) {
-		serve(resp, req, w.admitVm)
+		serve(resp, req, VmValidatePath, w.admitVm)
 	})
 	mux.HandleFunc(TemplateValidatePath, func(resp http.ResponseWriter, req *http.Request) {
-		serve(resp, req, w.admitTemplate)
+		serve(resp, req, TemplateValidatePath, w.admitTemplate)
+	})
+	mux.HandleFunc(VmiValidatePath, func(resp http.ResponseWriter, req *http.Request) {
+		serve(resp, req, VmiValidatePath, w.admitVmi)
+	})
+	mux.HandleFunc(VmMutatePath, func(resp http.ResponseWriter, req *http.Request) {
+		serve(resp, req, VmMutatePath, w.mutateVm)
+	})
+	mux.HandleFunc(ValidationPolicyValidatePath, func(resp http.ResponseWriter, req *http.Request) {
+		serve(resp, req, ValidationPolicyValidatePath, w.admitValidationPolicy)
 	})
 }
 
@@ -75,10 +129,35 @@ func (w *webhooks) admitVm(ar *admissionv1.AdmissionReview) *admissionv1.Admissi
 		return ToAdmissionResponseOK()
 	}
 
-	rules, err := getValidationRulesForVM(vm, w.informers.TemplateStore())
+	summaryUpdate, err := isValidationSummaryUpdate(ar, vm, w.config.ValidationSummaryWriter)
 	if err != nil {
 		return ToAdmissionResponseError(err)
 	}
+	if summaryUpdate {
+		return ToAdmissionResponseOK()
+	}
+
+	// The annotations of the VM are honored again when its VMI is created,
+	// so they cannot be ignored only during this admission.
+	warnings, response := w.checkValidationOverrides(ar, vm, false)
+	if response != nil {
+		return response
+	}
+
+	tmpl, rules, err := getValidationRulesForVM(vm, w.informers.TemplateStore(), w.informers.ValidationPolicyStore())
+	if err != nil {
+		return ToAdmissionResponseError(err)
+	}
+
+	if tmpl == nil && vmUsesTemplateRules(vm) {
+		missingTemplateWarning, response := w.checkMissingTemplate(ar, vm)
+		if response != nil {
+			return response
+		}
+		if missingTemplateWarning != "" {
+			warnings = append(warnings, missingTemplateWarning)
+		}
+	}
 
 	if vmJson, err := json.Marshal(vm); err == nil {
 		logger.Log.V(8).Info("admission vm", "json", vmJson)
@@ -86,19 +165,39 @@ func (w *webhooks) admitVm(ar *admissionv1.AdmissionReview) *admissionv1.Admissi
 		logger.Log.V(8).Info("cold not marshal admission vm to json", "error", err.Error())
 	}
 
+	if len(rules) > 0 {
+		if err := expandInstancetype(vm, w.informers); err != nil {
+			return ToAdmissionResponseError(err)
+		}
+	}
+
 	if rulesJson, err := json.Marshal(rules); err == nil {
 		logger.Log.V(8).Info("admission rules", "json", rulesJson)
 	} else {
 		logger.Log.V(8).Info("cold not marshal admission rules to json", "error", err.Error())
 	}
 
-	causes := ValidateVm(rules, vm)
-	if len(causes) > 0 {
-		metrics.IncTemplateValidatorRejected()
-		return ToAdmissionResponse(causes)
+	response = w.validateVm(vm, tmpl, rules)
+	response.Warnings = append(warnings, response.Warnings...)
+	return response
+}
+
+func (w *webhooks) validateVm(vm *kubevirtv1.VirtualMachine, tmpl *templatev1.Template, rules []validation.Rule) *admissionv1.AdmissionResponse {
+	result := EvaluateVm(rules, vm)
+	if result.Succeeded() {
+		return ToAdmissionResponseOK()
 	}
 
-	return ToAdmissionResponseOK()
+	if w.validationModeFor(tmpl) == ssp.TemplateValidatorModeAudit {
+		return w.auditVm(vm, tmpl, result.Violations())
+	}
+
+	metrics.IncTemplateValidatorRejected()
+	templateName, templateNamespace := templateNameAndNamespace(tmpl)
+	for _, violation := range result.Violations() {
+		metrics.IncTemplateValidatorRuleViolations(templateName, templateNamespace, violation.Ref.Name)
+	}
+	return ToAdmissionResponseViolations(result, w.config.StructuredRejectionCauses)
 }
 
 func (w *webhooks) admitTemplate(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
@@ -143,7 +242,12 @@ func (w *webhooks) admitTemplate(ar *admissionv1.AdmissionReview) *admissionv1.A
 	}
 }
 
-func serve(resp http.ResponseWriter, req *http.Request, admit admitFunc) {
+func serve(resp http.ResponseWriter, req *http.Request, webhook string, admit admitFunc) {
+	start := time.Now()
+	defer func() {
+		metrics.ObserveTemplateValidatorAdmissionDuration(webhook, time.Since(start))
+	}()
+
 	review, err := GetAdmissionReview(req)
 
 	logger.Log.V(8).Info("evaluating admission")
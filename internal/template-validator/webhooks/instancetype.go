@@ -0,0 +1,198 @@
+package validating
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/api/instancetype"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+
+	instancetypeapply "kubevirt.io/ssp-operator/internal/template-validator/instancetype"
+	"kubevirt.io/ssp-operator/internal/template-validator/logger"
+)
+
+// InstancetypeStores provides the objects of the instancetype and preference resources.
+type InstancetypeStores interface {
+	InstancetypeStore(resource string) cache.KeyGetter
+	// ControllerRevision returns the ControllerRevision, or nil if it does not exist.
+	ControllerRevision(namespace, name string) (*appsv1.ControllerRevision, error)
+}
+
+// expandInstancetype applies the instancetype and the preference referenced by the VM
+// to its spec, so validation rules check the values the VM will run with.
+// If the matcher has a revision name, the instancetype or preference captured by KubeVirt
+// in the ControllerRevision is used, because the VM runs with it even if the object was changed.
+// The current object is used until KubeVirt creates the ControllerRevision.
+func expandInstancetype(vm *kubevirtv1.VirtualMachine, stores InstancetypeStores) error {
+	if vm.Spec.Instancetype == nil && vm.Spec.Preference == nil {
+		return nil
+	}
+	if vm.Spec.Template == nil {
+		return nil
+	}
+
+	instancetypeSpec, err := getInstancetypeSpec(vm, stores)
+	if err != nil {
+		return err
+	}
+
+	preferenceSpec, err := getPreferenceSpec(vm, stores)
+	if err != nil {
+		return err
+	}
+
+	instancetypeapply.Apply(&vm.Spec.Template.Spec, instancetypeSpec, preferenceSpec)
+	return nil
+}
+
+func getInstancetypeSpec(vm *kubevirtv1.VirtualMachine, stores InstancetypeStores) (*instancetypev1beta1.VirtualMachineInstancetypeSpec, error) {
+	matcher := vm.Spec.Instancetype
+	if matcher == nil || matcher.Name == "" {
+		return nil, nil
+	}
+
+	var resource string
+	switch strings.ToLower(matcher.Kind) {
+	case "", instancetype.ClusterSingularResourceName:
+		resource = instancetype.ClusterPluralResourceName
+	case instancetype.SingularResourceName:
+		resource = instancetype.PluralResourceName
+	default:
+		logger.Log.V(4).Info("unknown instancetype kind", "vm", vm.Name, "kind", matcher.Kind)
+		return nil, nil
+	}
+
+	obj, err := getRevisionOrInstancetypeObject(stores, resource, vm, matcher.Name, matcher.RevisionName)
+	if obj == nil || err != nil {
+		return nil, err
+	}
+
+	switch instancetypeObj := obj.(type) {
+	case *instancetypev1beta1.VirtualMachineInstancetype:
+		return instancetypeObj.Spec.DeepCopy(), nil
+	case *instancetypev1beta1.VirtualMachineClusterInstancetype:
+		return instancetypeObj.Spec.DeepCopy(), nil
+	}
+	return nil, fmt.Errorf("unexpected object type %T in %s store", obj, resource)
+}
+
+func getPreferenceSpec(vm *kubevirtv1.VirtualMachine, stores InstancetypeStores) (*instancetypev1beta1.VirtualMachinePreferenceSpec, error) {
+	matcher := vm.Spec.Preference
+	if matcher == nil || matcher.Name == "" {
+		return nil, nil
+	}
+
+	var resource string
+	switch strings.ToLower(matcher.Kind) {
+	case "", instancetype.ClusterSingularPreferenceResourceName:
+		resource = instancetype.ClusterPluralPreferenceResourceName
+	case instancetype.SingularPreferenceResourceName:
+		resource = instancetype.PluralPreferenceResourceName
+	default:
+		logger.Log.V(4).Info("unknown preference kind", "vm", vm.Name, "kind", matcher.Kind)
+		return nil, nil
+	}
+
+	obj, err := getRevisionOrInstancetypeObject(stores, resource, vm, matcher.Name, matcher.RevisionName)
+	if obj == nil || err != nil {
+		return nil, err
+	}
+
+	switch preferenceObj := obj.(type) {
+	case *instancetypev1beta1.VirtualMachinePreference:
+		return preferenceObj.Spec.DeepCopy(), nil
+	case *instancetypev1beta1.VirtualMachineClusterPreference:
+		return preferenceObj.Spec.DeepCopy(), nil
+	}
+	return nil, fmt.Errorf("unexpected object type %T in %s store", obj, resource)
+}
+
+func getRevisionOrInstancetypeObject(stores InstancetypeStores, resource string, vm *kubevirtv1.VirtualMachine, name, revisionName string) (interface{}, error) {
+	if revisionName != "" {
+		obj, err := getRevisionObject(stores, resource, vm, revisionName)
+		if obj != nil || err != nil {
+			return obj, err
+		}
+	}
+	return getInstancetypeObject(stores.InstancetypeStore(resource), resource, vm.Namespace, name, vm.Name)
+}
+
+type revisionObject struct {
+	kind      string
+	newObject func() interface{}
+}
+
+// revisionObjects are the objects stored in ControllerRevisions for each resource.
+var revisionObjects = map[string]revisionObject{
+	instancetype.PluralResourceName: {
+		kind:      "VirtualMachineInstancetype",
+		newObject: func() interface{} { return &instancetypev1beta1.VirtualMachineInstancetype{} },
+	},
+	instancetype.ClusterPluralResourceName: {
+		kind:      "VirtualMachineClusterInstancetype",
+		newObject: func() interface{} { return &instancetypev1beta1.VirtualMachineClusterInstancetype{} },
+	},
+	instancetype.PluralPreferenceResourceName: {
+		kind:      "VirtualMachinePreference",
+		newObject: func() interface{} { return &instancetypev1beta1.VirtualMachinePreference{} },
+	},
+	instancetype.ClusterPluralPreferenceResourceName: {
+		kind:      "VirtualMachineClusterPreference",
+		newObject: func() interface{} { return &instancetypev1beta1.VirtualMachineClusterPreference{} },
+	},
+}
+
+// getRevisionObject decodes the instancetype or preference stored by KubeVirt in the ControllerRevision
+// in the namespace of the VM. All versions of the instancetype API use the same schema, so they are
+// decoded as v1beta1. It returns nil if the revision does not exist or does not contain an object
+// of the resource.
+func getRevisionObject(stores InstancetypeStores, resource string, vm *kubevirtv1.VirtualMachine, revisionName string) (interface{}, error) {
+	revision, err := stores.ControllerRevision(vm.Namespace, revisionName)
+	if err != nil {
+		return nil, err
+	}
+	if revision == nil {
+		logger.Log.V(4).Info("missing ControllerRevision referenced by VM", "revision", revisionName, "vm", vm.Name)
+		return nil, nil
+	}
+
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(revision.Data.Raw, &typeMeta); err != nil {
+		return nil, fmt.Errorf("failed to decode ControllerRevision %s/%s: %w", vm.Namespace, revisionName, err)
+	}
+	expected := revisionObjects[resource]
+	if typeMeta.GroupVersionKind().Group != instancetype.GroupName || typeMeta.Kind != expected.kind {
+		logger.Log.V(4).Info("ControllerRevision referenced by VM does not contain expected object",
+			"revision", revisionName, "vm", vm.Name, "apiVersion", typeMeta.APIVersion, "kind", typeMeta.Kind)
+		return nil, nil
+	}
+
+	obj := expected.newObject()
+	if err := json.Unmarshal(revision.Data.Raw, obj); err != nil {
+		return nil, fmt.Errorf("failed to decode ControllerRevision %s/%s: %w", vm.Namespace, revisionName, err)
+	}
+	return obj, nil
+}
+
+func getInstancetypeObject(store cache.KeyGetter, resource, namespace, name, vmName string) (interface{}, error) {
+	key := name
+	if resource == instancetype.PluralResourceName || resource == instancetype.PluralPreferenceResourceName {
+		key = namespace + "/" + name
+	}
+
+	obj, exists, err := store.GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		// The VM is validated without the expanded values.
+		logger.Log.V(4).Info("missing object referenced by VM", "resource", resource, "key", key, "vm", vmName)
+		return nil, nil
+	}
+	return obj, nil
+}
@@ -0,0 +1,211 @@
+package validating
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	k6tv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/api/instancetype"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+
+	"kubevirt.io/ssp-operator/internal/template-validator/validation"
+	"kubevirt.io/ssp-operator/internal/template-validator/validation/path"
+)
+
+type fakeInstancetypeStores struct {
+	stores map[string]cache.Store
+	// revisions are indexed by "namespace/name"
+	revisions map[string]*appsv1.ControllerRevision
+}
+
+func (f fakeInstancetypeStores) InstancetypeStore(resource string) cache.KeyGetter {
+	store, ok := f.stores[resource]
+	if !ok {
+		return cache.NewStore(cache.MetaNamespaceKeyFunc)
+	}
+	return store
+}
+
+func (f fakeInstancetypeStores) ControllerRevision(namespace, name string) (*appsv1.ControllerRevision, error) {
+	return f.revisions[namespace+"/"+name], nil
+}
+
+var _ = Describe("Instancetype expansion", func() {
+	const vmNamespace = "test-ns"
+
+	var (
+		stores fakeInstancetypeStores
+		vm     *k6tv1.VirtualMachine
+		rules  []validation.Rule
+	)
+
+	BeforeEach(func() {
+		instancetypeSpec := instancetypev1beta1.VirtualMachineInstancetypeSpec{
+			CPU: instancetypev1beta1.CPUInstancetype{Guest: 4},
+			Memory: instancetypev1beta1.MemoryInstancetype{
+				Guest: resource.MustParse("8Gi"),
+			},
+		}
+
+		clusterInstancetypes := cache.NewStore(cache.MetaNamespaceKeyFunc)
+		Expect(clusterInstancetypes.Add(&instancetypev1beta1.VirtualMachineClusterInstancetype{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-instancetype"},
+			Spec:       instancetypeSpec,
+		})).To(Succeed())
+
+		instancetypes := cache.NewStore(cache.MetaNamespaceKeyFunc)
+		Expect(instancetypes.Add(&instancetypev1beta1.VirtualMachineInstancetype{
+			ObjectMeta: metav1.ObjectMeta{Name: "instancetype", Namespace: vmNamespace},
+			Spec:       instancetypeSpec,
+		})).To(Succeed())
+
+		clusterPreferences := cache.NewStore(cache.MetaNamespaceKeyFunc)
+		Expect(clusterPreferences.Add(&instancetypev1beta1.VirtualMachineClusterPreference{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-preference"},
+			Spec: instancetypev1beta1.VirtualMachinePreferenceSpec{
+				CPU: &instancetypev1beta1.CPUPreferences{
+					PreferredCPUTopology: ptr.To(instancetypev1beta1.Cores),
+				},
+			},
+		})).To(Succeed())
+
+		revisionData, err := json.Marshal(&instancetypev1beta1.VirtualMachineClusterInstancetype{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: instancetypev1beta1.SchemeGroupVersion.String(),
+				Kind:       "VirtualMachineClusterInstancetype",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-instancetype"},
+			Spec: instancetypev1beta1.VirtualMachineInstancetypeSpec{
+				CPU: instancetypev1beta1.CPUInstancetype{Guest: 2},
+				Memory: instancetypev1beta1.MemoryInstancetype{
+					Guest: resource.MustParse("2Gi"),
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		stores = fakeInstancetypeStores{
+			stores: map[string]cache.Store{
+				instancetype.ClusterPluralResourceName:           clusterInstancetypes,
+				instancetype.PluralResourceName:                  instancetypes,
+				instancetype.ClusterPluralPreferenceResourceName: clusterPreferences,
+			},
+			revisions: map[string]*appsv1.ControllerRevision{
+				vmNamespace + "/cluster-instancetype-revision": {
+					ObjectMeta: metav1.ObjectMeta{Name: "cluster-instancetype-revision", Namespace: vmNamespace},
+					Data:       runtime.RawExtension{Raw: revisionData},
+				},
+			},
+		}
+
+		vm = &k6tv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vm",
+				Namespace: vmNamespace,
+			},
+			Spec: k6tv1.VirtualMachineSpec{
+				Template: &k6tv1.VirtualMachineInstanceTemplateSpec{},
+			},
+		}
+
+		rules = []validation.Rule{{
+			Name:    "memory",
+			Path:    *path.NewOrPanic("jsonpath::.spec.domain.memory.guest"),
+			Rule:    "integer",
+			Message: "Memory size not within range",
+			Min:     &path.IntOrPath{Int: 1024 * 1024 * 1024},
+			Max:     &path.IntOrPath{Int: 4 * 1024 * 1024 * 1024},
+		}, {
+			Name:    "cores",
+			Path:    *path.NewOrPanic("jsonpath::.spec.domain.cpu.cores"),
+			Rule:    "integer",
+			Message: "Core amount not within range",
+			Min:     &path.IntOrPath{Int: 1},
+			Max:     &path.IntOrPath{Int: 2},
+		}}
+	})
+
+	It("should not modify VM without instancetype", func() {
+		expected := vm.DeepCopy()
+		Expect(expandInstancetype(vm, stores)).To(Succeed())
+		Expect(vm).To(Equal(expected))
+	})
+
+	It("should expand cluster instancetype by default", func() {
+		vm.Spec.Instancetype = &k6tv1.InstancetypeMatcher{Name: "cluster-instancetype"}
+		Expect(expandInstancetype(vm, stores)).To(Succeed())
+
+		Expect(vm.Spec.Template.Spec.Domain.Memory.Guest).To(HaveValue(Equal(resource.MustParse("8Gi"))))
+		Expect(vm.Spec.Template.Spec.Domain.CPU.Sockets).To(Equal(uint32(4)))
+	})
+
+	It("should expand namespaced instancetype", func() {
+		vm.Spec.Instancetype = &k6tv1.InstancetypeMatcher{
+			Name: "instancetype",
+			Kind: "VirtualMachineInstancetype",
+		}
+		Expect(expandInstancetype(vm, stores)).To(Succeed())
+		Expect(vm.Spec.Template.Spec.Domain.Memory.Guest).To(HaveValue(Equal(resource.MustParse("8Gi"))))
+	})
+
+	It("should use preference for CPU topology", func() {
+		vm.Spec.Instancetype = &k6tv1.InstancetypeMatcher{Name: "cluster-instancetype"}
+		vm.Spec.Preference = &k6tv1.PreferenceMatcher{Name: "cluster-preference"}
+		Expect(expandInstancetype(vm, stores)).To(Succeed())
+		Expect(vm.Spec.Template.Spec.Domain.CPU.Cores).To(Equal(uint32(4)))
+	})
+
+	It("should expand instancetype from ControllerRevision", func() {
+		vm.Spec.Instancetype = &k6tv1.InstancetypeMatcher{
+			Name:         "cluster-instancetype",
+			RevisionName: "cluster-instancetype-revision",
+		}
+		Expect(expandInstancetype(vm, stores)).To(Succeed())
+
+		Expect(vm.Spec.Template.Spec.Domain.Memory.Guest).To(HaveValue(Equal(resource.MustParse("2Gi"))))
+		Expect(vm.Spec.Template.Spec.Domain.CPU.Sockets).To(Equal(uint32(2)))
+	})
+
+	It("should expand current instancetype if ControllerRevision does not exist", func() {
+		vm.Spec.Instancetype = &k6tv1.InstancetypeMatcher{
+			Name:         "cluster-instancetype",
+			RevisionName: "non-existing-revision",
+		}
+		Expect(expandInstancetype(vm, stores)).To(Succeed())
+		Expect(vm.Spec.Template.Spec.Domain.Memory.Guest).To(HaveValue(Equal(resource.MustParse("8Gi"))))
+	})
+
+	It("should expand current preference if ControllerRevision contains different object", func() {
+		vm.Spec.Instancetype = &k6tv1.InstancetypeMatcher{Name: "cluster-instancetype"}
+		vm.Spec.Preference = &k6tv1.PreferenceMatcher{
+			Name:         "cluster-preference",
+			RevisionName: "cluster-instancetype-revision",
+		}
+		Expect(expandInstancetype(vm, stores)).To(Succeed())
+		Expect(vm.Spec.Template.Spec.Domain.CPU.Cores).To(Equal(uint32(4)))
+	})
+
+	It("should not fail if instancetype does not exist", func() {
+		vm.Spec.Instancetype = &k6tv1.InstancetypeMatcher{Name: "non-existing"}
+		expected := vm.DeepCopy()
+		Expect(expandInstancetype(vm, stores)).To(Succeed())
+		Expect(vm).To(Equal(expected))
+	})
+
+	It("should reject VM violating rules after expansion", func() {
+		vm.Spec.Instancetype = &k6tv1.InstancetypeMatcher{Name: "cluster-instancetype"}
+		vm.Spec.Preference = &k6tv1.PreferenceMatcher{Name: "cluster-preference"}
+		Expect(expandInstancetype(vm, stores)).To(Succeed())
+
+		causes := ValidateVm(rules, vm)
+		Expect(causes).To(HaveLen(2))
+	})
+})
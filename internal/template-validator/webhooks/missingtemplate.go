@@ -0,0 +1,70 @@
+package validating
+
+import (
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
+	"kubevirt.io/ssp-operator/internal/template-validator/logger"
+)
+
+// ParseMissingTemplatePolicy converts a case-insensitive string to a ssp.MissingTemplatePolicy.
+func ParseMissingTemplatePolicy(value string) (ssp.MissingTemplatePolicy, error) {
+	return ParseEnum("missing templates policy", value, ssp.MissingTemplateWarn, ssp.MissingTemplateReject)
+}
+
+// vmUsesTemplateRules returns true if the rules for the VM come from the template it references.
+func vmUsesTemplateRules(vm *kubevirtv1.VirtualMachine) bool {
+	if _, skip := vm.Annotations[labels.VmSkipValidationAnnotationKey]; skip {
+		return false
+	}
+	if vm.Annotations[labels.VmValidationAnnotationKey] != "" {
+		return false
+	}
+	templateKeys := labels.GetTemplateKeys(vm)
+	return templateKeys.IsValid()
+}
+
+// checkMissingTemplate handles a VM whose template was not found. It returns a warning
+// with the Warn policy, and a rejecting response with the Reject policy.
+// Updates that do not change the template reference are not checked, so VMs
+// whose template was removed can still be modified.
+func (w *webhooks) checkMissingTemplate(ar *admissionv1.AdmissionReview, vm *kubevirtv1.VirtualMachine) (string, *admissionv1.AdmissionResponse) {
+	templateKeys := labels.GetTemplateKeys(vm)
+	templateKey := templateKeys.Get()
+
+	oldMeta, err := getOldObjectMeta(ar)
+	if err != nil {
+		return "", ToAdmissionResponseError(err)
+	}
+	if oldMeta != nil {
+		oldTemplateKeys := labels.GetTemplateKeys(oldMeta)
+		if oldTemplateKeys.Get().String() == templateKey.String() {
+			return "", nil
+		}
+	}
+
+	field := "metadata.labels"
+	if !templateKeys.LabelKey.IsValid() {
+		field = "metadata.annotations"
+	}
+
+	message := fmt.Sprintf("template %s referenced by the VM does not exist", templateKey.String())
+	logger.Log.V(2).Info("VM references missing template",
+		"vm", vm.Namespace+"/"+vm.Name,
+		"template", templateKey.String(),
+		"policy", w.config.MissingTemplates)
+
+	if w.config.MissingTemplates == ssp.MissingTemplateReject {
+		return "", ToAdmissionResponse([]metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueNotFound,
+			Message: message,
+			Field:   field,
+		}})
+	}
+	return message + ", the VM is not validated by its rules", nil
+}
@@ -0,0 +1,113 @@
+package validating
+
+import (
+	"encoding/json"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
+)
+
+var _ = Describe("Missing templates", func() {
+	var (
+		hooks *webhooks
+		vm    *k6tv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		hooks = NewWebhooks(nil, Config{}).(*webhooks)
+
+		vm = &k6tv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vm",
+				Namespace: "test-ns",
+				Labels: map[string]string{
+					labels.AnnotationTemplateNameKey:      "missing-template",
+					labels.AnnotationTemplateNamespaceKey: "test-template-ns",
+				},
+			},
+		}
+	})
+
+	createRequest := func() *admissionv1.AdmissionReview {
+		return &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+			},
+		}
+	}
+
+	DescribeTable("should parse missing templates policy", func(value string, expected ssp.MissingTemplatePolicy) {
+		policy, err := ParseMissingTemplatePolicy(value)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy).To(Equal(expected))
+	},
+		Entry("Warn", "Warn", ssp.MissingTemplateWarn),
+		Entry("reject", "reject", ssp.MissingTemplateReject),
+	)
+
+	It("should fail to parse unknown missing templates policy", func() {
+		_, err := ParseMissingTemplatePolicy("ignore")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should detect VMs using template rules", func() {
+		Expect(vmUsesTemplateRules(vm)).To(BeTrue())
+
+		vm.Annotations = map[string]string{
+			labels.VmSkipValidationAnnotationKey: "",
+		}
+		Expect(vmUsesTemplateRules(vm)).To(BeFalse())
+
+		vm.Annotations = map[string]string{
+			labels.VmValidationAnnotationKey: "[]",
+		}
+		Expect(vmUsesTemplateRules(vm)).To(BeFalse())
+
+		vm.Annotations = nil
+		vm.Labels = nil
+		Expect(vmUsesTemplateRules(vm)).To(BeFalse())
+	})
+
+	It("should warn by default", func() {
+		warning, response := hooks.checkMissingTemplate(createRequest(), vm)
+		Expect(response).To(BeNil())
+		Expect(warning).To(ContainSubstring("test-template-ns/missing-template"))
+	})
+
+	It("should reject with Reject policy", func() {
+		hooks.config.MissingTemplates = ssp.MissingTemplateReject
+
+		_, response := hooks.checkMissingTemplate(createRequest(), vm)
+		Expect(response).ToNot(BeNil())
+		Expect(response.Allowed).To(BeFalse())
+		Expect(response.Result.Code).To(Equal(int32(http.StatusUnprocessableEntity)))
+		Expect(response.Result.Details.Causes).To(HaveLen(1))
+		Expect(response.Result.Details.Causes[0].Field).To(Equal("metadata.labels"))
+	})
+
+	It("should not check updates that do not change the template reference", func() {
+		hooks.config.MissingTemplates = ssp.MissingTemplateReject
+
+		oldVmJson, err := json.Marshal(vm)
+		Expect(err).ToNot(HaveOccurred())
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				OldObject: runtime.RawExtension{Raw: oldVmJson},
+			},
+		}
+
+		warning, response := hooks.checkMissingTemplate(ar, vm)
+		Expect(response).To(BeNil())
+		Expect(warning).To(BeEmpty())
+	})
+})
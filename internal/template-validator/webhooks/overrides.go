@@ -0,0 +1,157 @@
+package validating
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
+	"kubevirt.io/ssp-operator/internal/template-validator/logger"
+)
+
+// The user setting the override annotations has to be allowed to perform
+// this verb on this resource in the namespace of the VM.
+const (
+	OverrideValidationsVerb     = "override"
+	OverrideValidationsGroup    = "ssp.kubevirt.io"
+	OverrideValidationsResource = "virtualmachinevalidations"
+)
+
+// overrideAnnotations are the annotations that skip or replace the validation rules from templates.
+var overrideAnnotations = []string{
+	labels.VmSkipValidationAnnotationKey,
+	labels.VmValidationAnnotationKey,
+}
+
+// ParseAnnotationOverridesPolicy converts a case-insensitive string to an ssp.AnnotationOverridesPolicy.
+func ParseAnnotationOverridesPolicy(value string) (ssp.AnnotationOverridesPolicy, error) {
+	return ParseEnum("annotation overrides policy", value, ssp.AnnotationOverridesAllow, ssp.AnnotationOverridesIgnore, ssp.AnnotationOverridesReject)
+}
+
+// OverrideAuthorizer checks if a user is allowed to override validation rules.
+type OverrideAuthorizer interface {
+	CanOverrideValidations(ctx context.Context, user authenticationv1.UserInfo, namespace string) (bool, error)
+}
+
+type subjectAccessReviewAuthorizer struct {
+	client authorizationv1client.SubjectAccessReviewInterface
+}
+
+var _ OverrideAuthorizer = &subjectAccessReviewAuthorizer{}
+
+func NewSubjectAccessReviewAuthorizer(client authorizationv1client.SubjectAccessReviewInterface) OverrideAuthorizer {
+	return &subjectAccessReviewAuthorizer{
+		client: client,
+	}
+}
+
+func (s *subjectAccessReviewAuthorizer) CanOverrideValidations(ctx context.Context, user authenticationv1.UserInfo, namespace string) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      OverrideValidationsVerb,
+				Group:     OverrideValidationsGroup,
+				Resource:  OverrideValidationsResource,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			Extra:  extra,
+			UID:    user.UID,
+		},
+	}
+
+	result, err := s.client.Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create SubjectAccessReview: %w", err)
+	}
+	return result.Status.Allowed, nil
+}
+
+// checkValidationOverrides verifies that the user of the request is allowed to set the annotations
+// that skip or replace validation rules. With the Ignore policy, annotations set by a user who is
+// not allowed to set them are removed from obj and a warning is returned, if ignorable is true.
+// Annotations are not ignorable, if they are persisted and honored later, like the annotations
+// of a VM when it is started. Otherwise, a rejecting response is returned.
+func (w *webhooks) checkValidationOverrides(ar *admissionv1.AdmissionReview, obj metav1.Object, ignorable bool) ([]string, *admissionv1.AdmissionResponse) {
+	if w.config.AnnotationOverrides == ssp.AnnotationOverridesAllow {
+		return nil, nil
+	}
+
+	oldMeta, err := getOldObjectMeta(ar)
+	if err != nil {
+		return nil, ToAdmissionResponseError(err)
+	}
+
+	var oldAnnotations map[string]string
+	if oldMeta != nil {
+		oldAnnotations = oldMeta.Annotations
+	}
+
+	changed := changedOverrideAnnotations(obj.GetAnnotations(), oldAnnotations)
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	if w.config.OverrideAuthorizer == nil {
+		return nil, ToAdmissionResponseError(fmt.Errorf("cannot verify that the user is allowed to set annotations %s", strings.Join(changed, ", ")))
+	}
+
+	allowed, err := w.config.OverrideAuthorizer.CanOverrideValidations(context.Background(), ar.Request.UserInfo, obj.GetNamespace())
+	if err != nil {
+		return nil, ToAdmissionResponseError(err)
+	}
+	if allowed {
+		return nil, nil
+	}
+
+	message := fmt.Sprintf("user %q is not allowed to set annotations %s, it requires the %q permission on %s.%s",
+		ar.Request.UserInfo.Username, strings.Join(changed, ", "),
+		OverrideValidationsVerb, OverrideValidationsResource, OverrideValidationsGroup)
+
+	logger.Log.V(2).Info("unauthorized validation override",
+		"object", obj.GetNamespace()+"/"+obj.GetName(),
+		"user", ar.Request.UserInfo.Username,
+		"annotations", changed,
+		"policy", w.config.AnnotationOverrides)
+
+	if w.config.AnnotationOverrides == ssp.AnnotationOverridesReject || !ignorable {
+		return nil, ToAdmissionResponseForbidden(message)
+	}
+
+	annotations := obj.GetAnnotations()
+	for _, key := range changed {
+		delete(annotations, key)
+	}
+	obj.SetAnnotations(annotations)
+	return []string{message + ", the annotations are ignored"}, nil
+}
+
+// changedOverrideAnnotations returns the override annotations that were added or modified.
+// Annotations that did not change were already checked when they were set.
+func changedOverrideAnnotations(annotations, oldAnnotations map[string]string) []string {
+	var changed []string
+	for _, key := range overrideAnnotations {
+		value, exists := annotations[key]
+		if !exists {
+			continue
+		}
+		if oldValue, oldExists := oldAnnotations[key]; oldExists && oldValue == value {
+			continue
+		}
+		changed = append(changed, key)
+	}
+	return changed
+}
@@ -0,0 +1,188 @@
+package validating
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
+)
+
+type fakeOverrideAuthorizer struct {
+	allowed bool
+	calls   int
+}
+
+func (f *fakeOverrideAuthorizer) CanOverrideValidations(_ context.Context, _ authenticationv1.UserInfo, _ string) (bool, error) {
+	f.calls++
+	return f.allowed, nil
+}
+
+type fakeSubjectAccessReviews struct {
+	created *authorizationv1.SubjectAccessReview
+	allowed bool
+}
+
+func (f *fakeSubjectAccessReviews) Create(_ context.Context, review *authorizationv1.SubjectAccessReview, _ metav1.CreateOptions) (*authorizationv1.SubjectAccessReview, error) {
+	f.created = review.DeepCopy()
+	result := review.DeepCopy()
+	result.Status.Allowed = f.allowed
+	return result, nil
+}
+
+var _ = Describe("Annotation overrides", func() {
+	const (
+		namespace = "test-ns"
+		username  = "test-user"
+	)
+
+	var (
+		authorizer *fakeOverrideAuthorizer
+		hooks      *webhooks
+		vm         *k6tv1.VirtualMachine
+	)
+
+	newAdmissionReview := func(operation admissionv1.Operation, oldVm *k6tv1.VirtualMachine) *admissionv1.AdmissionReview {
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Operation: operation,
+				UserInfo: authenticationv1.UserInfo{
+					Username: username,
+				},
+			},
+		}
+		if oldVm != nil {
+			oldVmJson, err := json.Marshal(oldVm)
+			Expect(err).ToNot(HaveOccurred())
+			ar.Request.OldObject = runtime.RawExtension{Raw: oldVmJson}
+		}
+		return ar
+	}
+
+	BeforeEach(func() {
+		authorizer = &fakeOverrideAuthorizer{}
+		hooks = NewWebhooks(nil, Config{
+			AnnotationOverrides: ssp.AnnotationOverridesReject,
+			OverrideAuthorizer:  authorizer,
+		}).(*webhooks)
+
+		vm = &k6tv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vm",
+				Namespace: namespace,
+				Annotations: map[string]string{
+					labels.VmSkipValidationAnnotationKey: "",
+				},
+			},
+		}
+	})
+
+	DescribeTable("should parse annotation overrides policy", func(value string, expected ssp.AnnotationOverridesPolicy) {
+		policy, err := ParseAnnotationOverridesPolicy(value)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy).To(Equal(expected))
+	},
+		Entry("Allow", "Allow", ssp.AnnotationOverridesAllow),
+		Entry("ignore", "ignore", ssp.AnnotationOverridesIgnore),
+		Entry("REJECT", "REJECT", ssp.AnnotationOverridesReject),
+	)
+
+	It("should fail to parse unknown annotation overrides policy", func() {
+		_, err := ParseAnnotationOverridesPolicy("deny")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should allow annotations by default", func() {
+		hooks = NewWebhooks(nil, Config{OverrideAuthorizer: authorizer}).(*webhooks)
+
+		warnings, response := hooks.checkValidationOverrides(newAdmissionReview(admissionv1.Create, nil), vm, false)
+		Expect(response).To(BeNil())
+		Expect(warnings).To(BeEmpty())
+		Expect(authorizer.calls).To(BeZero())
+	})
+
+	It("should allow annotations set by authorized user", func() {
+		authorizer.allowed = true
+
+		warnings, response := hooks.checkValidationOverrides(newAdmissionReview(admissionv1.Create, nil), vm, false)
+		Expect(response).To(BeNil())
+		Expect(warnings).To(BeEmpty())
+		Expect(authorizer.calls).To(Equal(1))
+	})
+
+	It("should reject annotations set by unauthorized user", func() {
+		_, response := hooks.checkValidationOverrides(newAdmissionReview(admissionv1.Create, nil), vm, false)
+		Expect(response).ToNot(BeNil())
+		Expect(response.Allowed).To(BeFalse())
+		Expect(response.Result.Code).To(Equal(int32(http.StatusForbidden)))
+		Expect(response.Result.Message).To(ContainSubstring(labels.VmSkipValidationAnnotationKey))
+	})
+
+	It("should ignore annotations set by unauthorized user", func() {
+		hooks.config.AnnotationOverrides = ssp.AnnotationOverridesIgnore
+		vm.Annotations[labels.VmValidationAnnotationKey] = "[]"
+
+		warnings, response := hooks.checkValidationOverrides(newAdmissionReview(admissionv1.Create, nil), vm, true)
+		Expect(response).To(BeNil())
+		Expect(warnings).To(HaveLen(1))
+		Expect(vm.Annotations).ToNot(HaveKey(labels.VmSkipValidationAnnotationKey))
+		Expect(vm.Annotations).ToNot(HaveKey(labels.VmValidationAnnotationKey))
+	})
+
+	It("should reject annotations set by unauthorized user, if they cannot be ignored", func() {
+		hooks.config.AnnotationOverrides = ssp.AnnotationOverridesIgnore
+
+		_, response := hooks.checkValidationOverrides(newAdmissionReview(admissionv1.Create, nil), vm, false)
+		Expect(response).ToNot(BeNil())
+		Expect(response.Allowed).To(BeFalse())
+		Expect(response.Result.Code).To(Equal(int32(http.StatusForbidden)))
+		Expect(vm.Annotations).To(HaveKey(labels.VmSkipValidationAnnotationKey))
+	})
+
+	It("should not check annotations that did not change", func() {
+		oldVm := vm.DeepCopy()
+
+		warnings, response := hooks.checkValidationOverrides(newAdmissionReview(admissionv1.Update, oldVm), vm, false)
+		Expect(response).To(BeNil())
+		Expect(warnings).To(BeEmpty())
+		Expect(authorizer.calls).To(BeZero())
+	})
+
+	It("should check annotations that changed", func() {
+		oldVm := vm.DeepCopy()
+		oldVm.Annotations = nil
+
+		_, response := hooks.checkValidationOverrides(newAdmissionReview(admissionv1.Update, oldVm), vm, false)
+		Expect(response).ToNot(BeNil())
+		Expect(authorizer.calls).To(Equal(1))
+	})
+
+	It("should create SubjectAccessReview for the user", func() {
+		reviews := &fakeSubjectAccessReviews{allowed: true}
+
+		sarAuthorizer := NewSubjectAccessReviewAuthorizer(reviews)
+		allowed, err := sarAuthorizer.CanOverrideValidations(context.Background(), authenticationv1.UserInfo{Username: username}, namespace)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+
+		Expect(reviews.created).ToNot(BeNil())
+		Expect(reviews.created.Spec.User).To(Equal(username))
+		Expect(reviews.created.Spec.ResourceAttributes).To(Equal(&authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      OverrideValidationsVerb,
+			Group:     OverrideValidationsGroup,
+			Resource:  OverrideValidationsResource,
+		}))
+	})
+})
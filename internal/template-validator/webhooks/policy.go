@@ -0,0 +1,137 @@
+package validating
+
+import (
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal/template-validator/logger"
+	"kubevirt.io/ssp-operator/internal/template-validator/validation"
+)
+
+// PolicyIndexer provides the VirtualMachineValidationPolicies indexed by namespace.
+// It is implemented by cache.Indexer.
+type PolicyIndexer interface {
+	ByIndex(indexName, indexedValue string) ([]interface{}, error)
+}
+
+// getValidationRulesFromPolicies returns the rules of all VirtualMachineValidationPolicies
+// in the namespace, whose selector matches the labels. Rule names are prefixed
+// with the name of the policy, so they are unique across policies and templates.
+func getValidationRulesFromPolicies(namespace string, objLabels map[string]string, policyGetter PolicyIndexer) ([]validation.Rule, error) {
+	if policyGetter == nil {
+		return nil, nil
+	}
+
+	objs, err := policyGetter.ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []validation.Rule
+	for _, obj := range objs {
+		policy, ok := obj.(*ssp.VirtualMachineValidationPolicy)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object type %T in validation policy store", obj)
+		}
+
+		matches, err := policyMatches(policy, objLabels)
+		if err != nil {
+			// A policy with an invalid selector should not block all VMs in the namespace.
+			logger.Log.Info("ignoring validation policy with invalid selector",
+				"policy", policy.Name,
+				"namespace", policy.Namespace,
+				"error", err.Error())
+			continue
+		}
+		if !matches {
+			continue
+		}
+
+		policyRules, err := convertPolicyRules(policy)
+		if err == nil {
+			err = validation.ValidateRules(policyRules)
+		}
+		if err != nil {
+			// A policy with invalid rules should not block all VMs in the namespace.
+			// Such policies are rejected by admitValidationPolicy, unless they were created
+			// while the validator was not running.
+			logger.Log.Info("ignoring validation policy with invalid rules",
+				"policy", policy.Name,
+				"namespace", policy.Namespace,
+				"error", err.Error())
+			continue
+		}
+		rules = append(rules, policyRules...)
+	}
+	return rules, nil
+}
+
+func policyMatches(policy *ssp.VirtualMachineValidationPolicy, objLabels map[string]string) (bool, error) {
+	if policy.Spec.Selector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(policy.Spec.Selector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(k8slabels.Set(objLabels)), nil
+}
+
+// convertPolicyRules converts the rules using JSON, because the API rules
+// have the same format as the rules in the template annotation.
+func convertPolicyRules(policy *ssp.VirtualMachineValidationPolicy) ([]validation.Rule, error) {
+	rulesJson, err := json.Marshal(policy.Spec.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := validation.ParseRules(rulesJson)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rules {
+		rules[i].Name = policy.Name + "/" + rules[i].Name
+	}
+	return rules, nil
+}
+
+// admitValidationPolicy rejects VirtualMachineValidationPolicies with an invalid selector or invalid rules.
+func (w *webhooks) admitValidationPolicy(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	policy, err := GetAdmissionReviewValidationPolicy(ar)
+	if err != nil {
+		return ToAdmissionResponseError(err)
+	}
+
+	if err := validatePolicy(policy); err != nil {
+		return ToAdmissionResponse([]metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: err.Error(),
+			Field:   "spec",
+		}})
+	}
+	return ToAdmissionResponseOK()
+}
+
+func validatePolicy(policy *ssp.VirtualMachineValidationPolicy) error {
+	if policy.Spec.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(policy.Spec.Selector); err != nil {
+			return fmt.Errorf("invalid selector: %w", err)
+		}
+	}
+
+	rules, err := convertPolicyRules(policy)
+	if err != nil {
+		return fmt.Errorf("invalid rules: %w", err)
+	}
+	if err := validation.ValidateRules(rules); err != nil {
+		return fmt.Errorf("invalid rules: %w", err)
+	}
+	return nil
+}
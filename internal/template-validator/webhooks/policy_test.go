@@ -0,0 +1,209 @@
+package validating
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
+)
+
+var _ = Describe("Validation policies", func() {
+	const (
+		namespace  = "test-ns"
+		policyName = "test-policy"
+	)
+
+	var (
+		policyStore cache.Indexer
+		vm          *k6tv1.VirtualMachine
+	)
+
+	newPolicy := func(name, ns string, selector *metav1.LabelSelector) *ssp.VirtualMachineValidationPolicy {
+		return &ssp.VirtualMachineValidationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+			},
+			Spec: ssp.VirtualMachineValidationPolicySpec{
+				Selector: selector,
+				Rules: []ssp.ValidationRule{{
+					Name:    "LimitCores",
+					Rule:    "integer",
+					Path:    "jsonpath::.spec.domain.cpu.cores",
+					Message: "Core amount not within range",
+					Min:     ptr.To(intstr.FromInt32(1)),
+					Max:     ptr.To(intstr.FromInt32(2)),
+				}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		policyStore = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+			cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+		})
+
+		vm = &k6tv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vm",
+				Namespace: namespace,
+				Labels: map[string]string{
+					"app": "test",
+				},
+			},
+			Spec: k6tv1.VirtualMachineSpec{
+				Template: &k6tv1.VirtualMachineInstanceTemplateSpec{
+					Spec: k6tv1.VirtualMachineInstanceSpec{
+						Domain: k6tv1.DomainSpec{
+							CPU: &k6tv1.CPU{Cores: 4},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("should return rules of policy without selector", func() {
+		Expect(policyStore.Add(newPolicy(policyName, namespace, nil))).To(Succeed())
+
+		tmpl, rules, err := getValidationRulesForVM(vm, nil, policyStore)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tmpl).To(BeNil())
+		Expect(rules).To(HaveLen(1))
+		Expect(rules[0].Name).To(Equal(policyName + "/LimitCores"))
+
+		causes := ValidateVm(rules, vm)
+		Expect(causes).To(HaveLen(1))
+		Expect(causes[0].Message).To(ContainSubstring("Core amount not within range"))
+	})
+
+	It("should ignore policies from other namespaces", func() {
+		Expect(policyStore.Add(newPolicy(policyName, "other-ns", nil))).To(Succeed())
+
+		_, rules, err := getValidationRulesForVM(vm, nil, policyStore)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(BeEmpty())
+	})
+
+	It("should return rules only of policies with matching selector", func() {
+		Expect(policyStore.Add(newPolicy("matching", namespace, &metav1.LabelSelector{
+			MatchLabels: map[string]string{"app": "test"},
+		}))).To(Succeed())
+		Expect(policyStore.Add(newPolicy("not-matching", namespace, &metav1.LabelSelector{
+			MatchLabels: map[string]string{"app": "other"},
+		}))).To(Succeed())
+
+		_, rules, err := getValidationRulesForVM(vm, nil, policyStore)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(1))
+		Expect(rules[0].Name).To(Equal("matching/LimitCores"))
+	})
+
+	It("should append policy rules to rules from VM annotation", func() {
+		vm.Annotations = map[string]string{
+			labels.VmValidationAnnotationKey: `[{
+				"name": "VmRule",
+				"path": "jsonpath::.spec.domain.cpu.cores",
+				"message": "Core amount not within range",
+				"rule": "integer",
+				"min": 1
+			}]`,
+		}
+		Expect(policyStore.Add(newPolicy(policyName, namespace, nil))).To(Succeed())
+
+		_, rules, err := getValidationRulesForVM(vm, nil, policyStore)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(2))
+		Expect(rules[0].Name).To(Equal("VmRule"))
+		Expect(rules[1].Name).To(Equal(policyName + "/LimitCores"))
+	})
+
+	It("should ignore policies with invalid rules", func() {
+		invalidPolicy := newPolicy("invalid", namespace, nil)
+		invalidPolicy.Spec.Rules[0].Rule = "unknown"
+		Expect(policyStore.Add(invalidPolicy)).To(Succeed())
+		Expect(policyStore.Add(newPolicy(policyName, namespace, nil))).To(Succeed())
+
+		_, rules, err := getValidationRulesForVM(vm, nil, policyStore)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(1))
+		Expect(rules[0].Name).To(Equal(policyName + "/LimitCores"))
+	})
+
+	It("should not return policy rules if VM skips validation", func() {
+		vm.Annotations = map[string]string{
+			labels.VmSkipValidationAnnotationKey: "",
+		}
+		Expect(policyStore.Add(newPolicy(policyName, namespace, nil))).To(Succeed())
+
+		_, rules, err := getValidationRulesForVM(vm, nil, policyStore)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(BeEmpty())
+	})
+
+	Context("admission", func() {
+		newAdmissionReview := func(policy *ssp.VirtualMachineValidationPolicy) *admissionv1.AdmissionReview {
+			raw, err := json.Marshal(policy)
+			Expect(err).ToNot(HaveOccurred())
+			return &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    ssp.GroupVersion.Group,
+						Version:  ssp.GroupVersion.Version,
+						Resource: "virtualmachinevalidationpolicies",
+					},
+					Object: runtime.RawExtension{Raw: raw},
+				},
+			}
+		}
+
+		It("should accept valid policy", func() {
+			policy := newPolicy(policyName, namespace, &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "test"},
+			})
+			response := (&webhooks{}).admitValidationPolicy(newAdmissionReview(policy))
+			Expect(response.Allowed).To(BeTrue())
+		})
+
+		DescribeTable("should reject invalid policy", func(modify func(policy *ssp.VirtualMachineValidationPolicy)) {
+			policy := newPolicy(policyName, namespace, nil)
+			modify(policy)
+			response := (&webhooks{}).admitValidationPolicy(newAdmissionReview(policy))
+			Expect(response.Allowed).To(BeFalse())
+		},
+			Entry("with invalid selector", func(policy *ssp.VirtualMachineValidationPolicy) {
+				policy.Spec.Selector = &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "app",
+						Operator: "Unknown",
+					}},
+				}
+			}),
+			Entry("with unknown rule type", func(policy *ssp.VirtualMachineValidationPolicy) {
+				policy.Spec.Rules[0].Rule = "unknown"
+			}),
+			Entry("with invalid path", func(policy *ssp.VirtualMachineValidationPolicy) {
+				policy.Spec.Rules[0].Path = ".spec.domain.cpu.cores"
+			}),
+			Entry("with duplicate rule names", func(policy *ssp.VirtualMachineValidationPolicy) {
+				policy.Spec.Rules = append(policy.Spec.Rules, policy.Spec.Rules[0])
+			}),
+			Entry("with invalid regex", func(policy *ssp.VirtualMachineValidationPolicy) {
+				policy.Spec.Rules[0].Rule = "regex"
+				policy.Spec.Rules[0].Regex = "q35["
+			}),
+		)
+	})
+})
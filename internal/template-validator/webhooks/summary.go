@@ -0,0 +1,123 @@
+package validating
+
+import (
+	"encoding/json"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/tools/cache"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
+)
+
+// ValidationSummary is a compact record of the last evaluation of the validation rules of a VM.
+// It is stored as JSON in the VmValidationSummaryAnnotationKey annotation.
+type ValidationSummary struct {
+	// ObservedGeneration is the generation of the VM that was evaluated.
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// Template is the namespace/name of the template the rules were read from.
+	Template string `json:"template,omitempty"`
+	// MissingTemplate is true if the VM references a template that does not exist.
+	MissingTemplate bool `json:"missingTemplate,omitempty"`
+	// Skipped is true if the VM has the annotation skipping validation.
+	Skipped bool `json:"skipped,omitempty"`
+	// Rules is the number of evaluated rules.
+	Rules int `json:"rules"`
+	// Violations are the names of the rules the VM violates.
+	Violations []string `json:"violations,omitempty"`
+	// Warnings are the names of the not satisfied rules that only produce a warning.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// RuleSources provides the objects the validation rules of a VM are read from.
+type RuleSources struct {
+	Templates     cache.KeyGetter
+	Policies      PolicyIndexer
+	Instancetypes InstancetypeStores
+}
+
+// SummarizeVm evaluates the validation rules of the VM the same way as the webhook,
+// and returns the summary of the result. The VM is not modified.
+func SummarizeVm(vm *kubevirtv1.VirtualMachine, sources RuleSources) (*ValidationSummary, error) {
+	summary := &ValidationSummary{
+		ObservedGeneration: vm.Generation,
+	}
+	if _, skip := vm.Annotations[labels.VmSkipValidationAnnotationKey]; skip {
+		summary.Skipped = true
+		return summary, nil
+	}
+
+	tmpl, rules, err := getValidationRulesForVM(vm, sources.Templates, sources.Policies)
+	if err != nil {
+		return nil, err
+	}
+
+	if tmpl != nil {
+		summary.Template = tmpl.Namespace + "/" + tmpl.Name
+	} else if vmUsesTemplateRules(vm) {
+		templateKeys := labels.GetTemplateKeys(vm)
+		summary.Template = templateKeys.Get().String()
+		summary.MissingTemplate = true
+	}
+
+	summary.Rules = len(rules)
+	if len(rules) == 0 {
+		return summary, nil
+	}
+
+	vm = vm.DeepCopy()
+	if sources.Instancetypes != nil {
+		if err := expandInstancetype(vm, sources.Instancetypes); err != nil {
+			return nil, err
+		}
+	}
+
+	result := EvaluateVm(rules, vm)
+	for _, violation := range result.Violations() {
+		if !violation.Ref.JustWarning {
+			summary.Violations = append(summary.Violations, violation.Ref.Name)
+		}
+	}
+	for _, report := range result.Status {
+		if report.Ref.JustWarning && !report.Skipped && !report.Satisfied && report.Error == nil {
+			summary.Warnings = append(summary.Warnings, report.Ref.Name)
+		}
+	}
+	return summary, nil
+}
+
+// isValidationSummaryUpdate returns true if the request only changes the validation summary
+// annotation of the VM and it is sent by the summary writer. Such updates are admitted,
+// so the summary can be written also for VMs that violate the rules.
+func isValidationSummaryUpdate(ar *admissionv1.AdmissionReview, vm *kubevirtv1.VirtualMachine, summaryWriter string) (bool, error) {
+	if summaryWriter == "" || ar.Request.UserInfo.Username != summaryWriter {
+		return false, nil
+	}
+	if ar.Request.Operation != admissionv1.Update || len(ar.Request.OldObject.Raw) == 0 {
+		return false, nil
+	}
+
+	oldVm := &kubevirtv1.VirtualMachine{}
+	if err := json.Unmarshal(ar.Request.OldObject.Raw, oldVm); err != nil {
+		return false, err
+	}
+
+	if oldVm.Annotations[labels.VmValidationSummaryAnnotationKey] == vm.Annotations[labels.VmValidationSummaryAnnotationKey] {
+		return false, nil
+	}
+
+	return equality.Semantic.DeepEqual(oldVm.Spec, vm.Spec) &&
+		equality.Semantic.DeepEqual(oldVm.Labels, vm.Labels) &&
+		equality.Semantic.DeepEqual(annotationsWithoutSummary(oldVm.Annotations), annotationsWithoutSummary(vm.Annotations)), nil
+}
+
+func annotationsWithoutSummary(annotations map[string]string) map[string]string {
+	result := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		if key != labels.VmValidationSummaryAnnotationKey {
+			result[key] = value
+		}
+	}
+	return result
+}
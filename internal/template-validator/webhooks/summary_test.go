@@ -0,0 +1,207 @@
+package validating
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	templatev1 "github.com/openshift/api/template/v1"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
+)
+
+var _ = Describe("Validation summary", func() {
+	const (
+		namespace    = "test-ns"
+		templateName = "test-template"
+	)
+
+	var (
+		templateStore cache.Store
+		vm            *k6tv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		templateStore = cache.NewStore(cache.MetaNamespaceKeyFunc)
+
+		vm = &k6tv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test-vm",
+				Namespace:  namespace,
+				Generation: 3,
+				Labels: map[string]string{
+					labels.AnnotationTemplateNameKey:      templateName,
+					labels.AnnotationTemplateNamespaceKey: namespace,
+				},
+			},
+			Spec: k6tv1.VirtualMachineSpec{
+				Template: &k6tv1.VirtualMachineInstanceTemplateSpec{
+					Spec: k6tv1.VirtualMachineInstanceSpec{
+						Domain: k6tv1.DomainSpec{
+							CPU: &k6tv1.CPU{Cores: 4},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	addTemplate := func() {
+		Expect(templateStore.Add(&templatev1.Template{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      templateName,
+				Namespace: namespace,
+				Annotations: map[string]string{
+					labels.AnnotationValidationKey: `[{
+						"name": "LimitCores",
+						"path": "jsonpath::.spec.domain.cpu.cores",
+						"message": "Core amount not within range",
+						"rule": "integer",
+						"min": 1,
+						"max": 2
+					}, {
+						"name": "RecommendedCores",
+						"path": "jsonpath::.spec.domain.cpu.cores",
+						"message": "Core amount not recommended",
+						"rule": "integer",
+						"max": 1,
+						"justWarning": true
+					}, {
+						"name": "MinCores",
+						"path": "jsonpath::.spec.domain.cpu.cores",
+						"message": "Too few cores",
+						"rule": "integer",
+						"min": 1
+					}]`,
+				},
+			},
+		})).To(Succeed())
+	}
+
+	It("should summarize violated rules and warnings", func() {
+		addTemplate()
+
+		summary, err := SummarizeVm(vm, RuleSources{Templates: templateStore})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(summary).To(Equal(&ValidationSummary{
+			ObservedGeneration: 3,
+			Template:           namespace + "/" + templateName,
+			Rules:              3,
+			Violations:         []string{"LimitCores"},
+			Warnings:           []string{"RecommendedCores"},
+		}))
+	})
+
+	It("should not modify the VM", func() {
+		addTemplate()
+		vm.Spec.Template.Spec.Domain.CPU.Sockets = 0
+		origVm := vm.DeepCopy()
+
+		_, err := SummarizeVm(vm, RuleSources{Templates: templateStore})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vm).To(Equal(origVm))
+	})
+
+	It("should report missing template", func() {
+		summary, err := SummarizeVm(vm, RuleSources{Templates: templateStore})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(summary).To(Equal(&ValidationSummary{
+			ObservedGeneration: 3,
+			Template:           namespace + "/" + templateName,
+			MissingTemplate:    true,
+		}))
+	})
+
+	It("should report skipped validation", func() {
+		addTemplate()
+		vm.Annotations = map[string]string{
+			labels.VmSkipValidationAnnotationKey: "",
+		}
+
+		summary, err := SummarizeVm(vm, RuleSources{Templates: templateStore})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(summary).To(Equal(&ValidationSummary{
+			ObservedGeneration: 3,
+			Skipped:            true,
+		}))
+	})
+
+	Context("summary updates", func() {
+		const summaryWriter = "system:serviceaccount:kubevirt:ssp-operator"
+
+		newUpdateReview := func(oldVm *k6tv1.VirtualMachine) *admissionv1.AdmissionReview {
+			oldVmJson, err := json.Marshal(oldVm)
+			Expect(err).ToNot(HaveOccurred())
+			return &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					OldObject: runtime.RawExtension{Raw: oldVmJson},
+					UserInfo: authenticationv1.UserInfo{
+						Username: summaryWriter,
+					},
+				},
+			}
+		}
+
+		It("should detect update of only the summary annotation", func() {
+			oldVm := vm.DeepCopy()
+			vm.Annotations = map[string]string{
+				labels.VmValidationSummaryAnnotationKey: `{"rules":0}`,
+			}
+
+			summaryUpdate, err := isValidationSummaryUpdate(newUpdateReview(oldVm), vm, summaryWriter)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(summaryUpdate).To(BeTrue())
+		})
+
+		It("should not detect update that also changes the spec", func() {
+			oldVm := vm.DeepCopy()
+			vm.Annotations = map[string]string{
+				labels.VmValidationSummaryAnnotationKey: `{"rules":0}`,
+			}
+			vm.Spec.Template.Spec.Domain.CPU.Cores = 1
+
+			summaryUpdate, err := isValidationSummaryUpdate(newUpdateReview(oldVm), vm, summaryWriter)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(summaryUpdate).To(BeFalse())
+		})
+
+		It("should not detect update of only the summary annotation by other user", func() {
+			oldVm := vm.DeepCopy()
+			vm.Annotations = map[string]string{
+				labels.VmValidationSummaryAnnotationKey: `{"rules":0}`,
+			}
+
+			review := newUpdateReview(oldVm)
+			review.Request.UserInfo.Username = "test-user"
+
+			summaryUpdate, err := isValidationSummaryUpdate(review, vm, summaryWriter)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(summaryUpdate).To(BeFalse())
+		})
+
+		It("should not detect update of only the summary annotation without summary writer", func() {
+			oldVm := vm.DeepCopy()
+			vm.Annotations = map[string]string{
+				labels.VmValidationSummaryAnnotationKey: `{"rules":0}`,
+			}
+
+			summaryUpdate, err := isValidationSummaryUpdate(newUpdateReview(oldVm), vm, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(summaryUpdate).To(BeFalse())
+		})
+
+		It("should not detect update that does not change the summary", func() {
+			summaryUpdate, err := isValidationSummaryUpdate(newUpdateReview(vm.DeepCopy()), vm, summaryWriter)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(summaryUpdate).To(BeFalse())
+		})
+	})
+})
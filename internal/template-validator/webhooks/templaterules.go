@@ -0,0 +1,38 @@
+package validating
+
+import (
+	templatev1 "github.com/openshift/api/template/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"kubevirt.io/ssp-operator/internal/template-validator/validation"
+)
+
+// templateRules caches the validation rules of templates for each template revision.
+var templateRules = validation.NewRuleCache()
+
+// templateRulesInvalidator returns an event handler for the template informer,
+// that removes rules of updated and deleted templates from the cache.
+func templateRulesInvalidator(ruleCache *validation.RuleCache) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldTmpl, ok := oldObj.(*templatev1.Template)
+			if !ok {
+				return
+			}
+			newTmpl, ok := newObj.(*templatev1.Template)
+			if ok && oldTmpl.ResourceVersion == newTmpl.ResourceVersion {
+				// Periodic resync, the template did not change
+				return
+			}
+			ruleCache.Delete(oldTmpl.UID)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if tmpl, ok := obj.(*templatev1.Template); ok {
+				ruleCache.Delete(tmpl.UID)
+			}
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package validating
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	templatev1 "github.com/openshift/api/template/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"kubevirt.io/ssp-operator/internal/template-validator/validation"
+)
+
+var _ = Describe("Template rules cache", func() {
+	var (
+		ruleCache *validation.RuleCache
+		handler   cache.ResourceEventHandler
+		tmpl      *templatev1.Template
+	)
+
+	BeforeEach(func() {
+		ruleCache = validation.NewRuleCache()
+		handler = templateRulesInvalidator(ruleCache)
+
+		tmpl = &templatev1.Template{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "test-template",
+				Namespace:       "test-ns",
+				UID:             "test-uid",
+				ResourceVersion: "1",
+			},
+		}
+
+		_, err := ruleCache.Get(tmpl.UID, tmpl.ResourceVersion, []byte("[]"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleCache.Len()).To(Equal(1))
+	})
+
+	It("should keep rules on resync", func() {
+		handler.OnUpdate(tmpl, tmpl.DeepCopy())
+		Expect(ruleCache.Len()).To(Equal(1))
+	})
+
+	It("should remove rules when template is updated", func() {
+		newTmpl := tmpl.DeepCopy()
+		newTmpl.ResourceVersion = "2"
+		handler.OnUpdate(tmpl, newTmpl)
+		Expect(ruleCache.Len()).To(Equal(0))
+	})
+
+	It("should remove rules when template is deleted", func() {
+		handler.OnDelete(tmpl)
+		Expect(ruleCache.Len()).To(Equal(0))
+	})
+
+	It("should remove rules when deleted template is in a tombstone", func() {
+		handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "test-ns/test-template", Obj: tmpl})
+		Expect(ruleCache.Len()).To(Equal(0))
+	})
+
+	It("should not modify cached rules when policy rules are added", func() {
+		rules := make([]validation.Rule, 1, 2)
+		rules[0].Name = "template-rule"
+
+		result := concatRules(rules, []validation.Rule{{Name: "policy-rule"}})
+		Expect(result).To(HaveLen(2))
+		Expect(rules[:2][1].Name).To(BeEmpty())
+	})
+})
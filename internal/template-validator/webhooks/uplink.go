@@ -20,22 +20,25 @@ func getParentTemplateForVM(vm *k6tv1.VirtualMachine, templateGetter cache.KeyGe
 		return nil, nil
 	}
 
-	cacheKey := templateKeys.Get().String()
+	return getTemplateByKey(templateKeys.Get().String(), vm.Name, templateGetter)
+}
+
+func getTemplateByKey(cacheKey string, vmName string, templateGetter cache.KeyGetter) (*templatev1.Template, error) {
 	obj, exists, err := templateGetter.GetByKey(cacheKey)
 	if err != nil {
 		logger.Log.V(8).Info("parent template not found",
 			"key", cacheKey,
-			"vm", vm.Name,
+			"vm", vmName,
 			"error", err)
 		return nil, err
 	}
 
 	if !exists {
-		logger.Log.V(4).Info("Missing parent template", "key", cacheKey, "vm", vm.Name)
+		logger.Log.V(4).Info("Missing parent template", "key", cacheKey, "vm", vmName)
 		return nil, nil
 	}
 
-	logger.Log.V(8).Info("found parent template for VM", "vm", vm.Name)
+	logger.Log.V(8).Info("found parent template for VM", "vm", vmName)
 	tmpl := obj.(*templatev1.Template)
 	// We must copy what is retrieved from the cache to allow modifying it.
 	// Modifying tmpl without DeepCopy would break the cache on modification.
@@ -63,24 +66,42 @@ func logVmTemplateKey(vmName string, targetName string, templateKey labels.Templ
 }
 
 func getValidationRulesFromTemplate(tmpl *templatev1.Template) ([]validation.Rule, error) {
-	return validation.ParseRules([]byte(tmpl.Annotations[labels.AnnotationValidationKey]))
+	return templateRules.Get(tmpl.UID, tmpl.ResourceVersion, []byte(tmpl.Annotations[labels.AnnotationValidationKey]))
 }
 
 func getValidationRulesFromVM(vm *k6tv1.VirtualMachine) ([]validation.Rule, error) {
 	return validation.ParseRules([]byte(vm.Annotations[labels.VmValidationAnnotationKey]))
 }
 
-func getValidationRulesForVM(vm *k6tv1.VirtualMachine, templateGetter cache.KeyGetter) ([]validation.Rule, error) {
+// getValidationRulesForVM returns the validation rules for the VM, followed by the rules
+// of the matching validation policies. If the rules come from the parent template,
+// the template is returned as well.
+func getValidationRulesForVM(vm *k6tv1.VirtualMachine, templateGetter cache.KeyGetter, policyGetter PolicyIndexer) (*templatev1.Template, []validation.Rule, error) {
 	// If the VM has the 'vm.kubevirt.io/skip-validations' annotations, skip validation
 	if _, skip := vm.Annotations[labels.VmSkipValidationAnnotationKey]; skip {
 		logger.Log.V(8).Info(fmt.Sprintf("skipped validation for VM [%s] in namespace [%s]", vm.Name, vm.Namespace))
-		return []validation.Rule{}, nil
+		return nil, []validation.Rule{}, nil
+	}
+
+	tmpl, rules, err := getOwnValidationRulesForVM(vm, templateGetter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policyRules, err := getValidationRulesFromPolicies(vm.Namespace, vm.Labels, policyGetter)
+	if err != nil {
+		return nil, nil, err
 	}
+	return tmpl, concatRules(rules, policyRules), nil
+}
 
+// getOwnValidationRulesForVM returns the rules from the VM annotation, or from the parent template.
+func getOwnValidationRulesForVM(vm *k6tv1.VirtualMachine, templateGetter cache.KeyGetter) (*templatev1.Template, []validation.Rule, error) {
 	// If the VM has the 'vm.kubevirt.io/validations' annotation applied, we will use the validation rules
 	// it contains instead of the validation rules from the template.
 	if vm.Annotations[labels.VmValidationAnnotationKey] != "" {
-		return getValidationRulesFromVM(vm)
+		rules, err := getValidationRulesFromVM(vm)
+		return nil, rules, err
 	}
 
 	tmpl, err := getParentTemplateForVM(vm, templateGetter)
@@ -88,7 +109,26 @@ func getValidationRulesForVM(vm *k6tv1.VirtualMachine, templateGetter cache.KeyG
 		// no template resources (kubevirt deployed on kubernetes, not OKD/OCP) or
 		// no parent template for this VM. In either case, we have nothing to do,
 		// and err is automatically correct
-		return nil, err
+		return nil, nil, err
+	}
+	rules, err := getValidationRulesFromTemplate(tmpl)
+	return tmpl, rules, err
+}
+
+func templateNameAndNamespace(tmpl *templatev1.Template) (string, string) {
+	if tmpl == nil {
+		return "", ""
+	}
+	return tmpl.Name, tmpl.Namespace
+}
+
+// concatRules returns a new slice containing the rules from both slices.
+// Rules of templates are shared by the rule cache, so they cannot be appended to.
+func concatRules(rules []validation.Rule, otherRules []validation.Rule) []validation.Rule {
+	if len(otherRules) == 0 {
+		return rules
 	}
-	return getValidationRulesFromTemplate(tmpl)
+	result := make([]validation.Rule, 0, len(rules)+len(otherRules))
+	result = append(result, rules...)
+	return append(result, otherRules...)
 }
@@ -5,11 +5,17 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	templatev1 "github.com/openshift/api/template/v1"
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	kubevirt "kubevirt.io/api/core/v1"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal/template-validator/validation"
 )
 
 func GetAdmissionReview(r *http.Request) (*admissionv1.AdmissionReview, error) {
@@ -46,6 +52,25 @@ func ToAdmissionResponseError(err error) *admissionv1.AdmissionResponse {
 	}
 }
 
+func ToAdmissionResponseForbidden(message string) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		Result: &metav1.Status{
+			Message: message,
+			Reason:  metav1.StatusReasonForbidden,
+			Code:    http.StatusForbidden,
+		},
+	}
+}
+
+// ToAdmissionResponsePatch returns a response admitting the object with the JSON patch applied.
+func ToAdmissionResponsePatch(patch []byte) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: ptr.To(admissionv1.PatchTypeJSONPatch),
+	}
+}
+
 func ToAdmissionResponse(causes []metav1.StatusCause) *admissionv1.AdmissionResponse {
 	globalMessage := ""
 	for _, cause := range causes {
@@ -55,10 +80,24 @@ func ToAdmissionResponse(causes []metav1.StatusCause) *admissionv1.AdmissionResp
 			globalMessage = fmt.Sprintf("%s, %s", globalMessage, cause.Message)
 		}
 	}
+	return toAdmissionResponseInvalid(globalMessage, causes)
+}
+
+// ToAdmissionResponseViolations returns a response rejecting the object that violates validation rules.
+// The message of the response is human-readable, and each cause describes one violated rule.
+// If structuredCauses is true, a machine-readable cause is added for each violated rule.
+func ToAdmissionResponseViolations(result *validation.Result, structuredCauses bool) *admissionv1.AdmissionResponse {
+	causes := result.ToStatusCauses()
+	if structuredCauses {
+		causes = append(causes, result.ToRuleViolationCauses()...)
+	}
+	return toAdmissionResponseInvalid(result.Message(), causes)
+}
 
+func toAdmissionResponseInvalid(message string, causes []metav1.StatusCause) *admissionv1.AdmissionResponse {
 	return &admissionv1.AdmissionResponse{
 		Result: &metav1.Status{
-			Message: globalMessage,
+			Message: message,
 			Reason:  metav1.StatusReasonInvalid,
 			Code:    http.StatusUnprocessableEntity,
 			Details: &metav1.StatusDetails{
@@ -74,10 +113,34 @@ func GetAdmissionReviewVM(ar *admissionv1.AdmissionReview) (*kubevirt.VirtualMac
 	}
 
 	newVM := &kubevirt.VirtualMachine{}
-	err := json.Unmarshal(ar.Request.Object.Raw, newVM)
+	err := decodeKubevirtObject(ar.Request, ar.Request.Object.Raw, newVM)
 	return newVM, err
 }
 
+func GetAdmissionReviewVMI(ar *admissionv1.AdmissionReview) (*kubevirt.VirtualMachineInstance, error) {
+	const resourceName = "virtualmachineinstances"
+	if ar.Request.Resource.Resource != resourceName {
+		return nil, fmt.Errorf("expected resource %v to be '%s'", ar.Request.Resource, resourceName)
+	}
+
+	newVMI := &kubevirt.VirtualMachineInstance{}
+	err := decodeKubevirtObject(ar.Request, ar.Request.Object.Raw, newVMI)
+	return newVMI, err
+}
+
+// decodeKubevirtObject decodes a v1 KubeVirt object. The webhooks use the Equivalent
+// match policy, so the API server converts objects of other versions to v1 before
+// sending them to the validator. Objects of other versions are rejected.
+func decodeKubevirtObject(request *admissionv1.AdmissionRequest, raw []byte, obj runtime.Object) error {
+	if request.Kind.Group != "" && request.Kind.Group != kubevirt.GroupVersion.Group {
+		return fmt.Errorf("expected group of kind %v to be '%s'", request.Kind, kubevirt.GroupVersion.Group)
+	}
+	if request.Kind.Version != "" && request.Kind.Version != kubevirt.GroupVersion.Version {
+		return fmt.Errorf("expected version of kind %v to be '%s'", request.Kind, kubevirt.GroupVersion.Version)
+	}
+	return json.Unmarshal(raw, obj)
+}
+
 func GetAdmissionReviewTemplate(ar *admissionv1.AdmissionReview) (*templatev1.Template, error) {
 	const resourceName = "templates"
 	if ar.Request.Resource.Resource != resourceName {
@@ -93,3 +156,40 @@ func GetAdmissionReviewTemplate(ar *admissionv1.AdmissionReview) (*templatev1.Te
 	err := json.Unmarshal(obj.Raw, template)
 	return template, err
 }
+
+func GetAdmissionReviewValidationPolicy(ar *admissionv1.AdmissionReview) (*ssp.VirtualMachineValidationPolicy, error) {
+	const resourceName = "virtualmachinevalidationpolicies"
+	if ar.Request.Resource.Resource != resourceName {
+		return nil, fmt.Errorf("expected resource %v to be '%s'", ar.Request.Resource, resourceName)
+	}
+
+	policy := &ssp.VirtualMachineValidationPolicy{}
+	err := json.Unmarshal(ar.Request.Object.Raw, policy)
+	return policy, err
+}
+
+// getOldObjectMeta returns the metadata of the old object for update requests, and nil for other requests.
+func getOldObjectMeta(ar *admissionv1.AdmissionReview) (*metav1.PartialObjectMetadata, error) {
+	if ar.Request.Operation != admissionv1.Update || len(ar.Request.OldObject.Raw) == 0 {
+		return nil, nil
+	}
+
+	oldObj := &metav1.PartialObjectMetadata{}
+	if err := json.Unmarshal(ar.Request.OldObject.Raw, oldObj); err != nil {
+		return nil, err
+	}
+	return oldObj, nil
+}
+
+// ParseEnum converts a case-insensitive string to one of the supported values.
+// The name describes the value in the returned error.
+func ParseEnum[T ~string](name string, value string, supported ...T) (T, error) {
+	quoted := make([]string, 0, len(supported))
+	for _, item := range supported {
+		if strings.EqualFold(value, string(item)) {
+			return item, nil
+		}
+		quoted = append(quoted, fmt.Sprintf("%q", item))
+	}
+	return "", fmt.Errorf("unknown %s: %q, supported values are %s", name, value, strings.Join(quoted, ", "))
+}
@@ -0,0 +1,79 @@
+package validating
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k6tv1 "kubevirt.io/api/core/v1"
+)
+
+var _ = Describe("Admission review decoding", func() {
+	newVmAdmissionReview := func(version string, vm *k6tv1.VirtualMachine) *admissionv1.AdmissionReview {
+		vm.APIVersion = k6tv1.GroupVersion.Group + "/" + version
+		vm.Kind = "VirtualMachine"
+		vmJson, err := json.Marshal(vm)
+		Expect(err).ToNot(HaveOccurred())
+
+		return &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   k6tv1.GroupVersion.Group,
+					Version: version,
+					Kind:    "VirtualMachine",
+				},
+				Resource: metav1.GroupVersionResource{
+					Group:    k6tv1.GroupVersion.Group,
+					Version:  version,
+					Resource: "virtualmachines",
+				},
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: vmJson},
+			},
+		}
+	}
+
+	var vm *k6tv1.VirtualMachine
+
+	BeforeEach(func() {
+		vm = &k6tv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vm",
+				Namespace: "test-ns",
+			},
+			Spec: k6tv1.VirtualMachineSpec{
+				Template: &k6tv1.VirtualMachineInstanceTemplateSpec{
+					Spec: k6tv1.VirtualMachineInstanceSpec{
+						Domain: k6tv1.DomainSpec{
+							CPU: &k6tv1.CPU{Cores: 4},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("should decode v1 VM", func() {
+		decodedVm, err := GetAdmissionReviewVM(newVmAdmissionReview("v1", vm.DeepCopy()))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decodedVm.APIVersion).To(Equal(k6tv1.GroupVersion.String()))
+		Expect(decodedVm.Spec).To(Equal(vm.Spec))
+	})
+
+	It("should reject VM of other version", func() {
+		_, err := GetAdmissionReviewVM(newVmAdmissionReview("v1alpha3", vm.DeepCopy()))
+		Expect(err).To(MatchError(ContainSubstring("expected version")))
+	})
+
+	It("should reject object from other API group", func() {
+		ar := newVmAdmissionReview("v1", vm)
+		ar.Request.Kind.Group = "example.com"
+
+		_, err := GetAdmissionReviewVM(ar)
+		Expect(err).To(HaveOccurred())
+	})
+})
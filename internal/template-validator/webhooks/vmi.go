@@ -0,0 +1,136 @@
+package validating
+
+import (
+	"fmt"
+
+	templatev1 "github.com/openshift/api/template/v1"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
+	"kubevirt.io/ssp-operator/internal/template-validator/logger"
+	"kubevirt.io/ssp-operator/internal/template-validator/validation"
+)
+
+// admitVmi validates a VMI when it is created, so the rules are enforced
+// when the workload starts, even if the VM was changed while it was stopped.
+func (w *webhooks) admitVmi(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	if ar.Request.Operation != admissionv1.Create {
+		return ToAdmissionResponseOK()
+	}
+
+	vmi, err := GetAdmissionReviewVMI(ar)
+	if err != nil {
+		return ToAdmissionResponseError(err)
+	}
+
+	vm := vmForVmi(vmi)
+
+	var warnings []string
+	if !isOwnedByVm(vmi) {
+		// Annotations of VMIs owned by a VM are not used to find the rules.
+		var response *admissionv1.AdmissionResponse
+		warnings, response = w.checkValidationOverrides(ar, vm, true)
+		if response != nil {
+			return response
+		}
+	}
+
+	tmpl, rules, err := getValidationRulesForVMI(vmi, vm, w.informers, w.informers.TemplateStore(), w.informers.ValidationPolicyStore())
+	if err != nil {
+		return ToAdmissionResponseError(err)
+	}
+
+	response := w.validateVm(vm, tmpl, rules)
+	response.Warnings = append(warnings, response.Warnings...)
+	return response
+}
+
+// VmMetadataGetter provides the metadata of VMs.
+type VmMetadataGetter interface {
+	// VmMetadata returns the metadata of the VM, or nil if it does not exist.
+	VmMetadata(namespace, name string) (*metav1.PartialObjectMetadata, error)
+}
+
+func isOwnedByVm(vmi *kubevirtv1.VirtualMachineInstance) bool {
+	owner := metav1.GetControllerOf(vmi)
+	return owner != nil && owner.Kind == kubevirtv1.VirtualMachineGroupVersionKind.Kind
+}
+
+// vmForVmi wraps the VMI in a VM, so the validation rules written for VMs can be evaluated on it.
+func vmForVmi(vmi *kubevirtv1.VirtualMachineInstance) *kubevirtv1.VirtualMachine {
+	return &kubevirtv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        vmi.Name,
+			Namespace:   vmi.Namespace,
+			Labels:      vmi.Labels,
+			Annotations: vmi.Annotations,
+		},
+		Spec: kubevirtv1.VirtualMachineSpec{
+			Template: &kubevirtv1.VirtualMachineInstanceTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      vmi.Labels,
+					Annotations: vmi.Annotations,
+				},
+				Spec: *vmi.Spec.DeepCopy(),
+			},
+		},
+	}
+}
+
+// getValidationRulesForVMI returns the validation rules for the VMI. The template is found
+// using the VM that owns the VMI, or using the VMI itself, if it was created directly.
+// Rules of the validation policies are matched against the labels of the owning VM,
+// or against the labels of the VMI, if it was created directly.
+func getValidationRulesForVMI(vmi *kubevirtv1.VirtualMachineInstance, vm *kubevirtv1.VirtualMachine, vmGetter VmMetadataGetter, templateGetter cache.KeyGetter, policyGetter PolicyIndexer) (*templatev1.Template, []validation.Rule, error) {
+	if !isOwnedByVm(vmi) {
+		return getValidationRulesForVM(vm, templateGetter, policyGetter)
+	}
+
+	// The owning VM is read on demand, because only VMs referencing a template are cached.
+	owner, err := vmGetter.VmMetadata(vmi.Namespace, metav1.GetControllerOf(vmi).Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if owner == nil {
+		// The owning VM was already deleted, it was validated when it was created or updated.
+		logger.Log.V(8).Info("VMI owner not found", "vmi", vmi.Name)
+		policyRules, err := getValidationRulesFromPolicies(vmi.Namespace, vmi.Labels, policyGetter)
+		return nil, policyRules, err
+	}
+
+	if _, skip := owner.Annotations[labels.VmSkipValidationAnnotationKey]; skip {
+		logger.Log.V(8).Info(fmt.Sprintf("skipped validation for VMI [%s] in namespace [%s]", vmi.Name, vmi.Namespace))
+		return nil, []validation.Rule{}, nil
+	}
+
+	tmpl, rules, err := getValidationRulesFromOwner(vmi, owner, templateGetter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policyRules, err := getValidationRulesFromPolicies(vmi.Namespace, owner.Labels, policyGetter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tmpl, concatRules(rules, policyRules), nil
+}
+
+// getValidationRulesFromOwner returns the template rules of the VM owning the VMI.
+func getValidationRulesFromOwner(vmi *kubevirtv1.VirtualMachineInstance, owner *metav1.PartialObjectMetadata, templateGetter cache.KeyGetter) (*templatev1.Template, []validation.Rule, error) {
+	templateKeys := labels.GetTemplateKeys(owner)
+	if _, ownRules := owner.Annotations[labels.VmValidationAnnotationKey]; ownRules || !templateKeys.IsValid() {
+		// The owning VM does not reference a template, or it carries its own rules.
+		// In both cases, the VM was already validated when it was created or updated.
+		return nil, nil, nil
+	}
+
+	tmpl, err := getTemplateByKey(templateKeys.Get().String(), vmi.Name, templateGetter)
+	if tmpl == nil || err != nil {
+		return nil, nil, err
+	}
+	rules, err := getValidationRulesFromTemplate(tmpl)
+	return tmpl, rules, err
+}
@@ -0,0 +1,229 @@
+package validating
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	templatev1 "github.com/openshift/api/template/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
+)
+
+// fakeVmMetadataGetter maps "namespace/name" to the VM metadata.
+type fakeVmMetadataGetter map[string]*metav1.PartialObjectMetadata
+
+func (f fakeVmMetadataGetter) VmMetadata(namespace, name string) (*metav1.PartialObjectMetadata, error) {
+	return f[namespace+"/"+name], nil
+}
+
+var _ = Describe("VMI validation", func() {
+	const (
+		namespace    = "test-ns"
+		templateName = "test-template"
+		vmName       = "test-vm"
+		rules        = `[{
+			"name": "LimitCores",
+			"path": "jsonpath::.spec.domain.cpu.cores",
+			"message": "Core amount not within range",
+			"rule": "integer",
+			"min": 1,
+			"max": 2
+		}]`
+	)
+
+	var (
+		templateStore cache.Store
+		vmGetter      fakeVmMetadataGetter
+		vmi           *k6tv1.VirtualMachineInstance
+		ownerVm       *k6tv1.VirtualMachine
+	)
+
+	addOwnerVm := func() {
+		vmGetter[namespace+"/"+vmName] = &metav1.PartialObjectMetadata{ObjectMeta: ownerVm.ObjectMeta}
+	}
+
+	BeforeEach(func() {
+		templateStore = cache.NewStore(cache.MetaNamespaceKeyFunc)
+		Expect(templateStore.Add(&templatev1.Template{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      templateName,
+				Namespace: namespace,
+				Annotations: map[string]string{
+					labels.AnnotationValidationKey: rules,
+				},
+			},
+		})).To(Succeed())
+
+		vmGetter = fakeVmMetadataGetter{}
+
+		ownerVm = &k6tv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vmName,
+				Namespace: namespace,
+				Labels: map[string]string{
+					labels.AnnotationTemplateNameKey:      templateName,
+					labels.AnnotationTemplateNamespaceKey: namespace,
+				},
+			},
+		}
+
+		vmi = &k6tv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vmName,
+				Namespace: namespace,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: k6tv1.GroupVersion.String(),
+					Kind:       k6tv1.VirtualMachineGroupVersionKind.Kind,
+					Name:       vmName,
+					Controller: ptr.To(true),
+				}},
+			},
+			Spec: k6tv1.VirtualMachineInstanceSpec{
+				Domain: k6tv1.DomainSpec{
+					CPU: &k6tv1.CPU{Cores: 4},
+				},
+			},
+		}
+	})
+
+	It("should wrap VMI spec in a VM", func() {
+		vm := vmForVmi(vmi)
+		Expect(vm.Name).To(Equal(vmi.Name))
+		Expect(vm.Namespace).To(Equal(vmi.Namespace))
+		Expect(vm.Spec.Template.Spec).To(Equal(vmi.Spec))
+	})
+
+	It("should use template of the owning VM", func() {
+		addOwnerVm()
+
+		vm := vmForVmi(vmi)
+		tmpl, vmRules, err := getValidationRulesForVMI(vmi, vm, vmGetter, templateStore, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tmpl).ToNot(BeNil())
+		Expect(tmpl.Name).To(Equal(templateName))
+		Expect(vmRules).To(HaveLen(1))
+
+		Expect(ValidateVm(vmRules, vm)).To(HaveLen(1))
+	})
+
+	It("should not return rules if the owning VM skips validation", func() {
+		ownerVm.Annotations = map[string]string{
+			labels.VmSkipValidationAnnotationKey: "",
+		}
+		addOwnerVm()
+
+		_, vmRules, err := getValidationRulesForVMI(vmi, vmForVmi(vmi), vmGetter, templateStore, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vmRules).To(BeEmpty())
+	})
+
+	It("should use template of the owning VM referencing it by annotations", func() {
+		ownerVm.Annotations = ownerVm.Labels
+		ownerVm.Labels = nil
+		addOwnerVm()
+
+		tmpl, vmRules, err := getValidationRulesForVMI(vmi, vmForVmi(vmi), vmGetter, templateStore, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tmpl).ToNot(BeNil())
+		Expect(vmRules).To(HaveLen(1))
+	})
+
+	It("should not return template rules if the owning VM has its own rules", func() {
+		ownerVm.Annotations = map[string]string{
+			labels.VmValidationAnnotationKey: rules,
+		}
+		addOwnerVm()
+
+		tmpl, vmRules, err := getValidationRulesForVMI(vmi, vmForVmi(vmi), vmGetter, templateStore, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tmpl).To(BeNil())
+		Expect(vmRules).To(BeEmpty())
+	})
+
+	It("should not return rules if the owning VM does not exist", func() {
+		tmpl, vmRules, err := getValidationRulesForVMI(vmi, vmForVmi(vmi), vmGetter, templateStore, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tmpl).To(BeNil())
+		Expect(vmRules).To(BeEmpty())
+	})
+
+	It("should return policy rules if the owning VM does not exist", func() {
+		policyStore := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+			cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+		})
+		Expect(policyStore.Add(&ssp.VirtualMachineValidationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-policy",
+				Namespace: namespace,
+			},
+			Spec: ssp.VirtualMachineValidationPolicySpec{
+				Rules: []ssp.ValidationRule{{
+					Name:    "LimitCores",
+					Rule:    "integer",
+					Path:    "jsonpath::.spec.domain.cpu.cores",
+					Message: "Core amount not within range",
+					Max:     ptr.To(intstr.FromInt32(2)),
+				}},
+			},
+		})).To(Succeed())
+
+		tmpl, vmRules, err := getValidationRulesForVMI(vmi, vmForVmi(vmi), vmGetter, templateStore, policyStore)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tmpl).To(BeNil())
+		Expect(vmRules).To(HaveLen(1))
+		Expect(ValidateVm(vmRules, vmForVmi(vmi))).To(HaveLen(1))
+	})
+
+	It("should match policy selector against labels of the owning VM", func() {
+		policyStore := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+			cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+		})
+		Expect(policyStore.Add(&ssp.VirtualMachineValidationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-policy",
+				Namespace: namespace,
+			},
+			Spec: ssp.VirtualMachineValidationPolicySpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"tier": "production"},
+				},
+				Rules: []ssp.ValidationRule{{
+					Name:    "LimitCores",
+					Rule:    "integer",
+					Path:    "jsonpath::.spec.domain.cpu.cores",
+					Message: "Core amount not within range",
+					Max:     ptr.To(intstr.FromInt32(2)),
+				}},
+			},
+		})).To(Succeed())
+
+		// Labels of the VMI come from the VM template, not from the VM
+		vmi.Labels = map[string]string{"tier": "development"}
+		ownerVm.Labels = map[string]string{"tier": "production"}
+		addOwnerVm()
+
+		_, vmRules, err := getValidationRulesForVMI(vmi, vmForVmi(vmi), vmGetter, templateStore, policyStore)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vmRules).To(HaveLen(1))
+		Expect(vmRules[0].Name).To(Equal("test-policy/LimitCores"))
+	})
+
+	It("should use template labels on VMI without owner", func() {
+		vmi.OwnerReferences = nil
+		vmi.Labels = map[string]string{
+			labels.AnnotationTemplateNameKey:      templateName,
+			labels.AnnotationTemplateNamespaceKey: namespace,
+		}
+
+		tmpl, vmRules, err := getValidationRulesForVMI(vmi, vmForVmi(vmi), vmGetter, templateStore, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tmpl).ToNot(BeNil())
+		Expect(vmRules).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,177 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
+)
+
+var (
+	goldenImageMetrics = []operatormetrics.Metric{
+		goldenImageReady,
+		goldenImageReadyTransitionTimestamp,
+		goldenImageLastImportTimestamp,
+		goldenImageUpToDateTimestamp,
+		goldenImageDigestInfo,
+		goldenImageImportFailures,
+		goldenImageStalePeriod,
+		goldenImageNotReadyPeriod,
+		goldenImageOrphanedVolume,
+	}
+
+	goldenImageLabels = []string{"name", "namespace"}
+
+	goldenImageReady = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_ssp_golden_image_ready",
+			Help: "Set to 1 if the DataSource of a golden image is ready, and to 0 otherwise",
+		},
+		goldenImageLabels,
+	)
+
+	goldenImageReadyTransitionTimestamp = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_ssp_golden_image_ready_transition_timestamp_seconds",
+			Help: "The time when the ready condition of the DataSource of a golden image last changed",
+		},
+		goldenImageLabels,
+	)
+
+	goldenImageLastImportTimestamp = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_ssp_golden_image_last_import_timestamp_seconds",
+			Help: "The time of the last successful import of a golden image by its DataImportCron",
+		},
+		goldenImageLabels,
+	)
+
+	goldenImageUpToDateTimestamp = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_ssp_golden_image_up_to_date_timestamp_seconds",
+			Help: "The last time a golden image was known to be up to date with its source by its DataImportCron",
+		},
+		goldenImageLabels,
+	)
+
+	goldenImageDigestInfo = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_ssp_golden_image_digest_info",
+			Help: "The digest of the source image of a golden image imported by its DataImportCron",
+		},
+		[]string{"name", "namespace", "digest"},
+	)
+
+	goldenImageImportFailures = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_ssp_golden_image_import_failures",
+			Help: "The number of failed attempts of the current import of a golden image by its DataImportCron",
+		},
+		goldenImageLabels,
+	)
+
+	goldenImageStalePeriod = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_ssp_golden_image_stale_period_seconds",
+			Help: "The time since a golden image was last known to be up to date, after which it is reported as stale",
+		},
+	)
+
+	goldenImageNotReadyPeriod = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_ssp_golden_image_not_ready_period_seconds",
+			Help: "The time the DataSource of a golden image can be not ready, before it is reported",
+		},
+	)
+
+	goldenImageOrphanedVolume = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_ssp_golden_image_orphaned_volume",
+			Help: "Set to 1 for each PVC or VolumeSnapshot in the golden images namespace that is not used by any DataSource or DataImportCron",
+		},
+		[]string{"name", "namespace", "kind"},
+	)
+)
+
+// GoldenImageStatus describes the state of a golden image DataSource and its DataImportCron.
+type GoldenImageStatus struct {
+	Name      string
+	Namespace string
+
+	Ready bool
+	// ReadyTransitionTime is zero if the DataSource does not have the ready condition.
+	ReadyTransitionTime time.Time
+
+	// AutoUpdate is true if the golden image is imported by a DataImportCron.
+	// The following fields are used only in that case.
+	AutoUpdate bool
+	// LastImportTime is zero if no import finished yet.
+	LastImportTime time.Time
+	// UpToDateTime is the last time the golden image was known to be up to date with its source.
+	// It is zero if the DataImportCron did not report it yet.
+	UpToDateTime   time.Time
+	Digest         string
+	ImportFailures int32
+}
+
+// SetGoldenImages replaces the metrics of all golden images with the metrics of the passed images.
+func SetGoldenImages(images []GoldenImageStatus) {
+	goldenImageReady.Reset()
+	goldenImageReadyTransitionTimestamp.Reset()
+	goldenImageLastImportTimestamp.Reset()
+	goldenImageUpToDateTimestamp.Reset()
+	goldenImageDigestInfo.Reset()
+	goldenImageImportFailures.Reset()
+
+	for i := range images {
+		image := &images[i]
+
+		ready := 0.0
+		if image.Ready {
+			ready = 1.0
+		}
+		goldenImageReady.WithLabelValues(image.Name, image.Namespace).Set(ready)
+
+		if !image.ReadyTransitionTime.IsZero() {
+			goldenImageReadyTransitionTimestamp.WithLabelValues(image.Name, image.Namespace).
+				Set(float64(image.ReadyTransitionTime.Unix()))
+		}
+		if !image.AutoUpdate {
+			continue
+		}
+
+		goldenImageImportFailures.WithLabelValues(image.Name, image.Namespace).Set(float64(image.ImportFailures))
+		if !image.LastImportTime.IsZero() {
+			goldenImageLastImportTimestamp.WithLabelValues(image.Name, image.Namespace).
+				Set(float64(image.LastImportTime.Unix()))
+		}
+		if !image.UpToDateTime.IsZero() {
+			goldenImageUpToDateTimestamp.WithLabelValues(image.Name, image.Namespace).
+				Set(float64(image.UpToDateTime.Unix()))
+		}
+		if image.Digest != "" {
+			goldenImageDigestInfo.WithLabelValues(image.Name, image.Namespace, image.Digest).Set(1)
+		}
+	}
+}
+
+// OrphanedGoldenImage identifies a golden image volume that is not used by any DataSource or DataImportCron.
+type OrphanedGoldenImage struct {
+	Name      string
+	Namespace string
+	// Kind is either PersistentVolumeClaim or VolumeSnapshot.
+	Kind string
+}
+
+// SetOrphanedGoldenImages replaces the reported orphaned golden image volumes.
+func SetOrphanedGoldenImages(volumes []OrphanedGoldenImage) {
+	goldenImageOrphanedVolume.Reset()
+	for _, volume := range volumes {
+		goldenImageOrphanedVolume.WithLabelValues(volume.Name, volume.Namespace, volume.Kind).Set(1)
+	}
+}
+
+// SetGoldenImageAlertPeriods sets the periods used by the golden image alerts.
+func SetGoldenImageAlertPeriods(stalePeriod, notReadyPeriod time.Duration) {
+	goldenImageStalePeriod.Set(stalePeriod.Seconds())
+	goldenImageNotReadyPeriod.Set(notReadyPeriod.Seconds())
+}
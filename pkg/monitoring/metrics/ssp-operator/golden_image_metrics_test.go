@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ioprometheusclient "github.com/prometheus/client_model/go"
+)
+
+var _ = Describe("golden_image_metrics", func() {
+	var (
+		transitionTime = time.Unix(1000, 0)
+		importTime     = time.Unix(2000, 0)
+		upToDateTime   = time.Unix(3000, 0)
+	)
+
+	getGaugeValue := func(write func(*ioprometheusclient.Metric) error) float64 {
+		dto := &ioprometheusclient.Metric{}
+		Expect(write(dto)).To(Succeed())
+		return dto.GetGauge().GetValue()
+	}
+
+	countMetrics := func(collector prometheus.Collector) int {
+		ch := make(chan prometheus.Metric, 10)
+		collector.Collect(ch)
+		close(ch)
+		return len(ch)
+	}
+
+	It("should set metrics of golden images", func() {
+		SetGoldenImages([]GoldenImageStatus{{
+			Name:                "fedora",
+			Namespace:           "os-images",
+			Ready:               true,
+			ReadyTransitionTime: transitionTime,
+			AutoUpdate:          true,
+			LastImportTime:      importTime,
+			UpToDateTime:        upToDateTime,
+			Digest:              "sha256:1234",
+			ImportFailures:      3,
+		}})
+
+		Expect(getGaugeValue(goldenImageReady.WithLabelValues("fedora", "os-images").Write)).To(Equal(1.0))
+		Expect(getGaugeValue(goldenImageReadyTransitionTimestamp.WithLabelValues("fedora", "os-images").Write)).To(Equal(1000.0))
+		Expect(getGaugeValue(goldenImageLastImportTimestamp.WithLabelValues("fedora", "os-images").Write)).To(Equal(2000.0))
+		Expect(getGaugeValue(goldenImageUpToDateTimestamp.WithLabelValues("fedora", "os-images").Write)).To(Equal(3000.0))
+		Expect(getGaugeValue(goldenImageDigestInfo.WithLabelValues("fedora", "os-images", "sha256:1234").Write)).To(Equal(1.0))
+		Expect(getGaugeValue(goldenImageImportFailures.WithLabelValues("fedora", "os-images").Write)).To(Equal(3.0))
+	})
+
+	It("should not set import metrics of golden images without auto-update", func() {
+		SetGoldenImages([]GoldenImageStatus{{
+			Name:      "fedora",
+			Namespace: "os-images",
+		}})
+
+		Expect(getGaugeValue(goldenImageReady.WithLabelValues("fedora", "os-images").Write)).To(Equal(0.0))
+		Expect(countMetrics(goldenImageReadyTransitionTimestamp)).To(BeZero())
+		Expect(countMetrics(goldenImageLastImportTimestamp)).To(BeZero())
+		Expect(countMetrics(goldenImageUpToDateTimestamp)).To(BeZero())
+		Expect(countMetrics(goldenImageImportFailures)).To(BeZero())
+	})
+
+	It("should remove metrics of removed golden images", func() {
+		SetGoldenImages([]GoldenImageStatus{{Name: "fedora", Namespace: "os-images"}})
+		SetGoldenImages([]GoldenImageStatus{{Name: "centos", Namespace: "os-images"}})
+
+		Expect(countMetrics(goldenImageReady)).To(Equal(1))
+	})
+
+	It("should replace orphaned golden images", func() {
+		SetOrphanedGoldenImages([]OrphanedGoldenImage{{
+			Name:      "fedora",
+			Namespace: "os-images",
+			Kind:      "PersistentVolumeClaim",
+		}, {
+			Name:      "centos",
+			Namespace: "os-images",
+			Kind:      "VolumeSnapshot",
+		}})
+		Expect(countMetrics(goldenImageOrphanedVolume)).To(Equal(2))
+		Expect(getGaugeValue(goldenImageOrphanedVolume.WithLabelValues("centos", "os-images", "VolumeSnapshot").Write)).To(Equal(1.0))
+
+		SetOrphanedGoldenImages(nil)
+		Expect(countMetrics(goldenImageOrphanedVolume)).To(BeZero())
+	})
+
+	It("should set alert periods", func() {
+		SetGoldenImageAlertPeriods(2*time.Hour, 10*time.Minute)
+
+		Expect(getGaugeValue(goldenImageStalePeriod.Write)).To(Equal(7200.0))
+		Expect(getGaugeValue(goldenImageNotReadyPeriod.Write)).To(Equal(600.0))
+	})
+})
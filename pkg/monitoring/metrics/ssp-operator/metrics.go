@@ -10,6 +10,7 @@ func SetupMetrics() error {
 
 	return operatormetrics.RegisterMetrics(
 		operatorMetrics,
+		goldenImageMetrics,
 		rbdMetrics,
 		templateMetrics,
 	)
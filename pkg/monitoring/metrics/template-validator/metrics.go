@@ -4,8 +4,16 @@ import (
 	"github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
 )
 
-func SetupMetrics() error {
-	return operatormetrics.RegisterMetrics(
+// SetupMetrics registers the template validator metrics. The missingTemplates function
+// is called when metrics are collected, it can be nil if metrics are not collected.
+func SetupMetrics(missingTemplates MissingTemplatesFunc) error {
+	if err := operatormetrics.RegisterMetrics(
 		templateMetrics,
+	); err != nil {
+		return err
+	}
+
+	return operatormetrics.RegisterCollector(
+		missingTemplatesCollector(missingTemplates),
 	)
 }
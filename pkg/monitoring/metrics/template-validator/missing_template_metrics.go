@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
+)
+
+// MissingTemplate is a template that is referenced by VMs, but does not exist.
+type MissingTemplate struct {
+	Name      string
+	Namespace string
+	Vms       int
+}
+
+type MissingTemplatesFunc func() []MissingTemplate
+
+var vmsWithMissingTemplate = operatormetrics.NewGaugeVec(
+	operatormetrics.MetricOpts{
+		Name: "kubevirt_ssp_template_validator_vms_with_missing_template",
+		Help: "The number of VMs referencing a template that does not exist. Rules of such templates are not validated",
+	},
+	[]string{"template_name", "template_namespace"},
+)
+
+func missingTemplatesCollector(missingTemplates MissingTemplatesFunc) operatormetrics.Collector {
+	return operatormetrics.Collector{
+		Metrics: []operatormetrics.Metric{
+			vmsWithMissingTemplate,
+		},
+		CollectCallback: func() []operatormetrics.CollectorResult {
+			if missingTemplates == nil {
+				return nil
+			}
+
+			var results []operatormetrics.CollectorResult
+			for _, template := range missingTemplates() {
+				results = append(results, operatormetrics.CollectorResult{
+					Metric: vmsWithMissingTemplate,
+					Labels: []string{template.Name, template.Namespace},
+					Value:  float64(template.Vms),
+				})
+			}
+			return results
+		},
+	}
+}
@@ -1,12 +1,18 @@
 package metrics
 
 import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
 )
 
 var (
 	templateMetrics = []operatormetrics.Metric{
 		templateValidatorRejected,
+		templateValidatorRuleViolations,
+		templateValidatorAuditViolations,
+		templateValidatorAdmissionDuration,
 	}
 
 	templateValidatorRejected = operatormetrics.NewCounter(
@@ -15,8 +21,48 @@ var (
 			Help: "The total number of rejected template validators",
 		},
 	)
+
+	templateValidatorRuleViolations = operatormetrics.NewCounterVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_ssp_template_validator_rule_violations_total",
+			Help: "The total number of validation rule violations by rejected VMs",
+		},
+		[]string{"template_name", "template_namespace", "rule_name"},
+	)
+
+	templateValidatorAuditViolations = operatormetrics.NewCounterVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_ssp_template_validator_audit_violations_total",
+			Help: "The total number of validation rule violations by VMs admitted in audit mode",
+		},
+		[]string{"template_name", "template_namespace", "rule_name"},
+	)
+
+	templateValidatorAdmissionDuration = operatormetrics.NewHistogramVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_ssp_template_validator_admission_duration_seconds",
+			Help: "The time it takes the template validator to process an admission review",
+		},
+		prometheus.HistogramOpts{
+			Buckets: []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+		},
+		[]string{"webhook"},
+	)
 )
 
 func IncTemplateValidatorRejected() {
 	templateValidatorRejected.Inc()
 }
+
+// IncTemplateValidatorRuleViolations is called once for every rule that caused a VM to be rejected.
+func IncTemplateValidatorRuleViolations(templateName, templateNamespace, ruleName string) {
+	templateValidatorRuleViolations.WithLabelValues(templateName, templateNamespace, ruleName).Inc()
+}
+
+func IncTemplateValidatorAuditViolations(templateName, templateNamespace, ruleName string) {
+	templateValidatorAuditViolations.WithLabelValues(templateName, templateNamespace, ruleName).Inc()
+}
+
+func ObserveTemplateValidatorAdmissionDuration(webhook string, duration time.Duration) {
+	templateValidatorAdmissionDuration.WithLabelValues(webhook).Observe(duration.Seconds())
+}
@@ -60,10 +60,13 @@ func operatorAlerts() []promv1.Rule {
 		},
 		{
 			Alert: "SSPHighRateRejectedVms",
-			Expr:  intstr.FromString("cluster:kubevirt_ssp_template_validator_rejected:increase1h > 5"),
-			For:   ptr.To[promv1.Duration]("5m"),
+			// One alert is fired for each template and rule that rejected VMs,
+			// while the overall rejection rate is high.
+			Expr: intstr.FromString("cluster:kubevirt_ssp_template_validator_rule_violations:increase1h > 0 and on() cluster:kubevirt_ssp_template_validator_rejected:increase1h > 5"),
+			For:  ptr.To[promv1.Duration]("5m"),
 			Annotations: map[string]string{
-				"summary": "High rate of rejected Vms.",
+				"summary":     "High rate of rejected Vms.",
+				"description": "VMs created from template '{{ $labels.template_namespace }}/{{ $labels.template_name }}' were rejected by validation rule '{{ $labels.rule_name }}'.",
 			},
 			Labels: map[string]string{
 				severityAlertLabelKey:     "warning",
@@ -82,6 +85,35 @@ func operatorAlerts() []promv1.Rule {
 				healthImpactAlertLabelKey: "none",
 			},
 		},
+		{
+			Alert: "SSPGoldenImageNotReady",
+			// The period is configured in the SSP CR and exposed by the operator as a metric.
+			Expr: intstr.FromString("(max by (name, namespace) (kubevirt_ssp_golden_image_ready) == 0) and on(name, namespace) " +
+				"(time() - max by (name, namespace) (kubevirt_ssp_golden_image_ready_transition_timestamp_seconds) > on() group_left() max(kubevirt_ssp_golden_image_not_ready_period_seconds))"),
+			Annotations: map[string]string{
+				"summary":     "Golden image DataSource '{{ $labels.namespace }}/{{ $labels.name }}' is not ready.",
+				"description": "VMs cannot be created from the golden image '{{ $labels.namespace }}/{{ $labels.name }}', because its DataSource is not ready for longer than the configured period.",
+			},
+			Labels: map[string]string{
+				severityAlertLabelKey:     "warning",
+				healthImpactAlertLabelKey: "none",
+			},
+		},
+		{
+			Alert: "SSPGoldenImageStale",
+			// The image is not stale if its source did not change, because the DataImportCron
+			// reports it up to date each time it polls the source.
+			Expr: intstr.FromString("time() - max by (name, namespace) (kubevirt_ssp_golden_image_up_to_date_timestamp_seconds) " +
+				"> on() group_left() max(kubevirt_ssp_golden_image_stale_period_seconds)"),
+			Annotations: map[string]string{
+				"summary":     "Golden image '{{ $labels.namespace }}/{{ $labels.name }}' was not up to date for longer than the configured period.",
+				"description": "The DataImportCron of the golden image '{{ $labels.namespace }}/{{ $labels.name }}' did not import the latest version of its source, or did not poll the source, for longer than the configured period.",
+			},
+			Labels: map[string]string{
+				severityAlertLabelKey:     "warning",
+				healthImpactAlertLabelKey: "none",
+			},
+		},
 		{
 			Alert: "VMStorageClassWarning",
 			Expr: intstr.FromString(withVMLabel(
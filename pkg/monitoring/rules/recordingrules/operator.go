@@ -9,6 +9,8 @@ import (
 const (
 	CommonTemplatesRestoredIncreaseQuery   = "sum(increase(kubevirt_ssp_common_templates_restored_total{pod=~'ssp-operator.*'}[1h]))"
 	TemplateValidatorRejectedIncreaseQuery = "sum(increase(kubevirt_ssp_template_validator_rejected_total{pod=~'virt-template-validator.*'}[1h]))"
+
+	TemplateValidatorRuleViolationsIncreaseQuery = "sum by (template_name, template_namespace, rule_name) (increase(kubevirt_ssp_template_validator_rule_violations_total{pod=~'virt-template-validator.*'}[1h]))"
 )
 
 func operatorRecordingRules() []operatorrules.RecordingRule {
@@ -77,6 +79,14 @@ func operatorRecordingRules() []operatorrules.RecordingRule {
 			MetricType: operatormetrics.GaugeType,
 			Expr:       intstr.FromString(TemplateValidatorRejectedIncreaseQuery + " OR on() vector(0)"),
 		},
+		{
+			MetricsOpts: operatormetrics.MetricOpts{
+				Name: "cluster:kubevirt_ssp_template_validator_rule_violations:increase1h",
+				Help: "The increase in the number of validation rule violations by rejected VMs, over the last hour, labeled with the template and the violated rule",
+			},
+			MetricType: operatormetrics.GaugeType,
+			Expr:       intstr.FromString(TemplateValidatorRuleViolationsIncreaseQuery),
+		},
 		{
 			MetricsOpts: operatormetrics.MetricOpts{
 				Name: "kubevirt_ssp_common_templates_restored_increase",
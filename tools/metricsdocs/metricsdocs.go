@@ -17,7 +17,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := validatorMetrics.SetupMetrics(); err != nil {
+	if err := validatorMetrics.SetupMetrics(nil); err != nil {
 		panic(err)
 	}
 
@@ -34,7 +34,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := validatorMetrics.SetupMetrics(); err != nil {
+	if err := validatorMetrics.SetupMetrics(nil); err != nil {
 		panic(err)
 	}
 
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/go-logr/logr"
+	templatev1 "github.com/openshift/api/template/v1"
+	"github.com/spf13/cobra"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/yaml"
+
+	"kubevirt.io/ssp-operator/internal/template-validator/labels"
+	"kubevirt.io/ssp-operator/internal/template-validator/validation"
+	validating "kubevirt.io/ssp-operator/internal/template-validator/webhooks"
+)
+
+const (
+	exitCodeValidationFailed = 1
+	exitCodeError            = 2
+)
+
+type validateFlags struct {
+	vmFile       string
+	templateFile string
+	rulesFile    string
+}
+
+var (
+	f       validateFlags
+	rootCmd = &cobra.Command{
+		Use:   "validate-vm",
+		Short: "Validates a VirtualMachine against template validation rules",
+		Long: `validate-vm evaluates the validation rules of a template, or rules from a JSON file,
+on a VirtualMachine manifest, the same way as the template validator webhook does.
+
+It exits with code 1 if the VirtualMachine violates the rules, and with code 2 on other errors.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			succeeded, err := runValidation(f, os.Stdout)
+			if err != nil {
+				// Ignoring returned error: no reasonable way to handle it.
+				_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(exitCodeError)
+			}
+			if !succeeded {
+				os.Exit(exitCodeValidationFailed)
+			}
+		},
+	}
+)
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		// Ignoring returned error: no reasonable way to handle it.
+		_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitCodeError)
+	}
+}
+
+func init() {
+	rootCmd.Flags().StringVar(&f.vmFile, "vm", "", "Location of the VirtualMachine yaml to validate (required)")
+	rootCmd.Flags().StringVar(&f.templateFile, "template", "", "Location of the template yaml containing the validation rules")
+	rootCmd.Flags().StringVar(&f.rulesFile, "rules", "", "Location of the json file containing the validation rules")
+
+	if err := rootCmd.MarkFlagRequired("vm"); err != nil {
+		panic(err)
+	}
+	rootCmd.MarkFlagsOneRequired("template", "rules")
+	rootCmd.MarkFlagsMutuallyExclusive("template", "rules")
+
+	// The validation package logs through controller-runtime,
+	// its output is not useful for users of this command.
+	ctrl.SetLogger(logr.Discard())
+}
+
+func runValidation(flags validateFlags, out io.Writer) (bool, error) {
+	vm := &kubevirtv1.VirtualMachine{}
+	if err := readYamlFile(flags.vmFile, vm); err != nil {
+		return false, fmt.Errorf("failed to read VirtualMachine: %w", err)
+	}
+	if vm.Spec.Template == nil {
+		return false, errors.New("the VirtualMachine does not have .spec.template")
+	}
+
+	rules, err := readRules(flags)
+	if err != nil {
+		return false, err
+	}
+
+	result := validating.EvaluateVm(rules, vm)
+	if err := printResult(result, out); err != nil {
+		return false, fmt.Errorf("failed to print validation result: %w", err)
+	}
+	return result.Succeeded(), nil
+}
+
+func readRules(flags validateFlags) ([]validation.Rule, error) {
+	var rulesJson []byte
+	switch {
+	case flags.templateFile != "":
+		tmpl := &templatev1.Template{}
+		if err := readYamlFile(flags.templateFile, tmpl); err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		rulesJson = []byte(tmpl.Annotations[labels.AnnotationValidationKey])
+	case flags.rulesFile != "":
+		data, err := os.ReadFile(flags.rulesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rules: %w", err)
+		}
+		rulesJson = data
+	default:
+		return nil, errors.New("either a template or a rules file has to be specified")
+	}
+
+	rules, err := validation.ParseRules(rulesJson)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse validation rules: %w", err)
+	}
+	return rules, nil
+}
+
+func readYamlFile(fileName string, obj any) error {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, obj)
+}
+
+func printResult(result *validation.Result, out io.Writer) error {
+	writer := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	// Ignoring returned error: tabwriter returns errors from Flush()
+	_, _ = fmt.Fprintln(writer, "RULE\tSKIPPED\tSATISFIED\tMESSAGE")
+	for _, report := range result.Status {
+		message := report.Message
+		if report.Error != nil {
+			message = report.Error.Error()
+		}
+		violated := report.Error != nil || (!report.Skipped && !report.Satisfied)
+		if violated && report.Ref.Message != "" {
+			message = report.Ref.Message + ": " + message
+		}
+		// Ignoring returned error: tabwriter returns errors from Flush()
+		_, _ = fmt.Fprintf(writer, "%s\t%t\t%t\t%s\n", report.Ref.Name, report.Skipped, report.Satisfied, message)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	status := "PASSED"
+	if !result.Succeeded() {
+		status = "FAILED"
+	}
+	_, err := fmt.Fprintf(out, "\nValidation %s\n", status)
+	return err
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testVm = `apiVersion: kubevirt.io/v1
+kind: VirtualMachine
+metadata:
+  name: test-vm
+spec:
+  template:
+    spec:
+      domain:
+        cpu:
+          cores: 4
+        devices: {}
+`
+
+	testRules = `[{
+  "name": "LimitCores",
+  "path": "jsonpath::.spec.domain.cpu.cores",
+  "message": "Core amount not within range",
+  "rule": "integer",
+  "min": 1,
+  "max": %d
+}]`
+
+	testTemplate = `apiVersion: template.openshift.io/v1
+kind: Template
+metadata:
+  name: test-template
+  annotations:
+    validations: |
+      [{
+        "name": "LimitCores",
+        "path": "jsonpath::.spec.domain.cpu.cores",
+        "message": "Core amount not within range",
+        "rule": "integer",
+        "min": 1,
+        "max": 2
+      }]
+objects: []
+`
+)
+
+var _ = Describe("validate-vm", func() {
+	var (
+		tmpDir string
+		out    *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		tmpDir = GinkgoT().TempDir()
+		out = &bytes.Buffer{}
+	})
+
+	writeFile := func(name, content string) string {
+		fileName := filepath.Join(tmpDir, name)
+		Expect(os.WriteFile(fileName, []byte(content), 0o600)).To(Succeed())
+		return fileName
+	}
+
+	It("should succeed when rules from file are satisfied", func() {
+		succeeded, err := runValidation(validateFlags{
+			vmFile:    writeFile("vm.yaml", testVm),
+			rulesFile: writeFile("rules.json", fmt.Sprintf(testRules, 8)),
+		}, out)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(succeeded).To(BeTrue())
+		Expect(out.String()).To(ContainSubstring("LimitCores"))
+		Expect(out.String()).To(ContainSubstring("Validation PASSED"))
+	})
+
+	It("should fail when rules from file are violated", func() {
+		succeeded, err := runValidation(validateFlags{
+			vmFile:    writeFile("vm.yaml", testVm),
+			rulesFile: writeFile("rules.json", fmt.Sprintf(testRules, 2)),
+		}, out)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(succeeded).To(BeFalse())
+		Expect(out.String()).To(ContainSubstring("Core amount not within range"))
+		Expect(out.String()).To(ContainSubstring("Validation FAILED"))
+	})
+
+	It("should fail when rules from template are violated", func() {
+		succeeded, err := runValidation(validateFlags{
+			vmFile:       writeFile("vm.yaml", testVm),
+			templateFile: writeFile("template.yaml", testTemplate),
+		}, out)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(succeeded).To(BeFalse())
+		Expect(out.String()).To(ContainSubstring("Core amount not within range"))
+	})
+
+	It("should return error for invalid rules", func() {
+		_, err := runValidation(validateFlags{
+			vmFile:    writeFile("vm.yaml", testVm),
+			rulesFile: writeFile("rules.json", "not json"),
+		}, out)
+		Expect(err).To(MatchError(ContainSubstring("failed to parse validation rules")))
+	})
+
+	It("should return error for missing VM file", func() {
+		_, err := runValidation(validateFlags{
+			vmFile:    filepath.Join(tmpDir, "missing.yaml"),
+			rulesFile: writeFile("rules.json", fmt.Sprintf(testRules, 2)),
+		}, out)
+		Expect(err).To(MatchError(ContainSubstring("failed to read VirtualMachine")))
+	})
+})
+
+func TestValidateVm(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "validate-vm Suite")
+}
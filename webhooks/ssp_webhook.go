@@ -19,9 +19,13 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"path"
+	"slices"
+	"strings"
 
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	rbac "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"kubevirt.io/controller-lifecycle-operator-sdk/api"
@@ -100,6 +104,30 @@ func (s *sspValidator) validateSspObject(ctx context.Context, ssp *sspv1beta3.SS
 		return nil, fmt.Errorf("dataImportCronTemplates validation error: %w", err)
 	}
 
+	if err := validateDataSourceAliases(ssp); err != nil {
+		return nil, fmt.Errorf("dataSourceAliases validation error: %w", err)
+	}
+
+	if err := validateDataSourceArchitectureOverrides(ssp); err != nil {
+		return nil, fmt.Errorf("dataSourceArchitectureOverrides validation error: %w", err)
+	}
+
+	if err := validateDisabledDataImportCrons(ssp); err != nil {
+		return nil, fmt.Errorf("disabledDataImportCrons validation error: %w", err)
+	}
+
+	if err := validateGoldenImageStoragePolicy(ssp); err != nil {
+		return nil, fmt.Errorf("goldenImageStoragePolicy validation error: %w", err)
+	}
+
+	if err := validateGoldenImageRegistryMirrors(ssp); err != nil {
+		return nil, fmt.Errorf("goldenImageRegistryMirrors validation error: %w", err)
+	}
+
+	if err := validateGoldenImageAccess(ssp); err != nil {
+		return nil, fmt.Errorf("goldenImageAccess validation error: %w", err)
+	}
+
 	if err := s.validatePlacement(ctx, ssp); err != nil {
 		return nil, fmt.Errorf("placement api validation error: %w", err)
 	}
@@ -202,6 +230,110 @@ func validateDataImportCronTemplates(ssp *sspv1beta3.SSP) error {
 	return nil
 }
 
+func validateDataSourceAliases(ssp *sspv1beta3.SSP) error {
+	managedDataSources := make(map[string]struct{}, len(ssp.Spec.CommonTemplates.DataImportCronTemplates))
+	for _, cronTemplate := range ssp.Spec.CommonTemplates.DataImportCronTemplates {
+		managedDataSources[cronTemplate.Spec.ManagedDataSource] = struct{}{}
+	}
+
+	aliasNames := make(map[string]struct{}, len(ssp.Spec.CommonTemplates.DataSourceAliases))
+	for _, alias := range ssp.Spec.CommonTemplates.DataSourceAliases {
+		if _, exists := aliasNames[alias.Name]; exists {
+			return fmt.Errorf("duplicate alias %q", alias.Name)
+		}
+		if alias.Name == alias.DataSource {
+			return fmt.Errorf("alias %q refers to itself", alias.Name)
+		}
+		if _, exists := managedDataSources[alias.Name]; exists {
+			return fmt.Errorf("alias %q has the same name as a DataSource managed by a DataImportCron", alias.Name)
+		}
+		aliasNames[alias.Name] = struct{}{}
+	}
+	return nil
+}
+
+func validateDataSourceArchitectureOverrides(ssp *sspv1beta3.SSP) error {
+	overrideNames := make(map[string]struct{}, len(ssp.Spec.CommonTemplates.DataSourceArchitectureOverrides))
+	for _, override := range ssp.Spec.CommonTemplates.DataSourceArchitectureOverrides {
+		if _, exists := overrideNames[override.Name]; exists {
+			return fmt.Errorf("duplicate override of DataSource %q", override.Name)
+		}
+		overrideNames[override.Name] = struct{}{}
+
+		for _, archStr := range override.Architectures {
+			if _, err := architecture.ToArch(archStr); err != nil {
+				return fmt.Errorf("invalid architecture of DataSource %q: %w", override.Name, err)
+			}
+		}
+
+		if override.DefaultArchitecture == "" {
+			continue
+		}
+		if _, err := architecture.ToArch(override.DefaultArchitecture); err != nil {
+			return fmt.Errorf("invalid default architecture of DataSource %q: %w", override.Name, err)
+		}
+		if len(override.Architectures) > 0 && !slices.Contains(override.Architectures, override.DefaultArchitecture) {
+			return fmt.Errorf("default architecture of DataSource %q is not in its architectures", override.Name)
+		}
+	}
+	return nil
+}
+
+func validateDisabledDataImportCrons(ssp *sspv1beta3.SSP) error {
+	for _, disabled := range ssp.Spec.CommonTemplates.DisabledDataImportCrons {
+		if disabled.Architecture == "" {
+			continue
+		}
+		if _, err := architecture.ToArch(disabled.Architecture); err != nil {
+			return fmt.Errorf("invalid architecture of DataImportCron %q: %w", disabled.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateGoldenImageStoragePolicy(ssp *sspv1beta3.SSP) error {
+	policy := ssp.Spec.CommonTemplates.GoldenImageStoragePolicy
+	if policy == nil {
+		return nil
+	}
+	for _, override := range policy.SizeOverrides {
+		if _, err := path.Match(override.DataSourcePattern, ""); err != nil {
+			return fmt.Errorf("invalid DataSource pattern %q: %w", override.DataSourcePattern, err)
+		}
+	}
+	return nil
+}
+
+func validateGoldenImageRegistryMirrors(ssp *sspv1beta3.SSP) error {
+	for _, mirror := range ssp.Spec.CommonTemplates.GoldenImageRegistryMirrors {
+		// The transport of the URL, like "docker://", is kept when the prefix is replaced.
+		if strings.Contains(mirror.Source, "://") {
+			return fmt.Errorf("source %q must not contain the transport", mirror.Source)
+		}
+		if strings.Contains(mirror.Mirror, "://") {
+			return fmt.Errorf("mirror %q must not contain the transport", mirror.Mirror)
+		}
+	}
+	return nil
+}
+
+func validateGoldenImageAccess(ssp *sspv1beta3.SSP) error {
+	access := ssp.Spec.CommonTemplates.GoldenImageAccess
+	if access == nil {
+		return nil
+	}
+	for _, viewer := range access.Viewers {
+		if viewer.Kind == rbac.ServiceAccountKind {
+			if viewer.Namespace == "" {
+				return fmt.Errorf("namespace of ServiceAccount %q is not set", viewer.Name)
+			}
+		} else if viewer.Namespace != "" {
+			return fmt.Errorf("namespace must not be set for %s %q", viewer.Kind, viewer.Name)
+		}
+	}
+	return nil
+}
+
 func newSspValidator(clt client.Client) *sspValidator {
 	return &sspValidator{apiClient: clt}
 }
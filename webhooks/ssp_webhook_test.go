@@ -25,8 +25,10 @@ import (
 
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/controller-lifecycle-operator-sdk/api"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -306,6 +308,231 @@ var _ = Describe("SSP Validation", func() {
 			})
 		})
 
+		Context("DataSourceAliases", func() {
+			DescribeTable("should validate aliases", func(aliases []sspv1beta3.DataSourceAlias, expectedError string) {
+				cronTemplate := sspv1beta3.DataImportCronTemplate{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "custom-cron",
+					},
+					Spec: cdiv1beta1.DataImportCronSpec{
+						ManagedDataSource: "custom-image",
+					},
+				}
+				ssp := &sspv1beta3.SSP{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-ssp",
+						Namespace: "test-ns",
+					},
+					Spec: sspv1beta3.SSPSpec{
+						CommonTemplates: sspv1beta3.CommonTemplates{
+							DataSourceAliases:       aliases,
+							DataImportCronTemplates: []sspv1beta3.DataImportCronTemplate{cronTemplate},
+						},
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, ssp)
+				if expectedError == "" {
+					Expect(err).ToNot(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(expectedError)))
+				}
+			},
+				Entry("valid aliases", []sspv1beta3.DataSourceAlias{
+					{Name: "rhel-latest", DataSource: "rhel9"},
+					{Name: "fedora-stable", DataSource: "fedora"},
+				}, ""),
+				Entry("duplicate alias", []sspv1beta3.DataSourceAlias{
+					{Name: "rhel-latest", DataSource: "rhel9"},
+					{Name: "rhel-latest", DataSource: "rhel8"},
+				}, "duplicate alias \"rhel-latest\""),
+				Entry("alias referring to itself", []sspv1beta3.DataSourceAlias{
+					{Name: "rhel-latest", DataSource: "rhel-latest"},
+				}, "alias \"rhel-latest\" refers to itself"),
+				Entry("alias with the name of a DataSource managed by a DataImportCron", []sspv1beta3.DataSourceAlias{
+					{Name: "custom-image", DataSource: "rhel9"},
+				}, "alias \"custom-image\" has the same name as a DataSource managed by a DataImportCron"),
+			)
+		})
+
+		Context("DataSourceArchitectureOverrides", func() {
+			DescribeTable("should validate overrides", func(overrides []sspv1beta3.DataSourceArchitectureOverride, expectedError string) {
+				ssp := &sspv1beta3.SSP{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-ssp",
+						Namespace: "test-ns",
+					},
+					Spec: sspv1beta3.SSPSpec{
+						CommonTemplates: sspv1beta3.CommonTemplates{
+							DataSourceArchitectureOverrides: overrides,
+						},
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, ssp)
+				if expectedError == "" {
+					Expect(err).ToNot(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(expectedError)))
+				}
+			},
+				Entry("valid overrides", []sspv1beta3.DataSourceArchitectureOverride{
+					{Name: "fedora", Architectures: []string{"amd64", "arm64"}, DefaultArchitecture: "arm64"},
+					{Name: "rhel9", DefaultArchitecture: "amd64"},
+				}, ""),
+				Entry("duplicate override", []sspv1beta3.DataSourceArchitectureOverride{
+					{Name: "fedora", Architectures: []string{"amd64"}},
+					{Name: "fedora", Architectures: []string{"arm64"}},
+				}, "duplicate override of DataSource \"fedora\""),
+				Entry("invalid architecture", []sspv1beta3.DataSourceArchitectureOverride{
+					{Name: "fedora", Architectures: []string{"unknown-arch"}},
+				}, "invalid architecture of DataSource \"fedora\""),
+				Entry("invalid default architecture", []sspv1beta3.DataSourceArchitectureOverride{
+					{Name: "fedora", DefaultArchitecture: "unknown-arch"},
+				}, "invalid default architecture of DataSource \"fedora\""),
+				Entry("default architecture not in architectures", []sspv1beta3.DataSourceArchitectureOverride{
+					{Name: "fedora", Architectures: []string{"amd64"}, DefaultArchitecture: "arm64"},
+				}, "default architecture of DataSource \"fedora\" is not in its architectures"),
+			)
+		})
+
+		Context("DisabledDataImportCrons", func() {
+			It("should fail if architecture is invalid", func() {
+				ssp := &sspv1beta3.SSP{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-ssp",
+						Namespace: "test-ns",
+					},
+					Spec: sspv1beta3.SSPSpec{
+						CommonTemplates: sspv1beta3.CommonTemplates{
+							DisabledDataImportCrons: []sspv1beta3.DisabledDataImportCron{{
+								Name:         "centos-stream9-image-cron",
+								Architecture: "unknown-arch",
+							}},
+						},
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, ssp)
+				Expect(err).To(MatchError(ContainSubstring("invalid architecture of DataImportCron")))
+
+				ssp.Spec.CommonTemplates.DisabledDataImportCrons[0].Architecture = "arm64"
+
+				_, err = validator.ValidateCreate(ctx, ssp)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("GoldenImageStoragePolicy", func() {
+			It("should fail if DataSource pattern is invalid", func() {
+				ssp := &sspv1beta3.SSP{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-ssp",
+						Namespace: "test-ns",
+					},
+					Spec: sspv1beta3.SSPSpec{
+						CommonTemplates: sspv1beta3.CommonTemplates{
+							GoldenImageStoragePolicy: &sspv1beta3.GoldenImageStoragePolicy{
+								SizeOverrides: []sspv1beta3.GoldenImageSizeOverride{{
+									DataSourcePattern: "win[",
+									Size:              resource.MustParse("50Gi"),
+								}},
+							},
+						},
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, ssp)
+				Expect(err).To(MatchError(ContainSubstring("invalid DataSource pattern")))
+
+				ssp.Spec.CommonTemplates.GoldenImageStoragePolicy.SizeOverrides[0].DataSourcePattern = "win*"
+
+				_, err = validator.ValidateCreate(ctx, ssp)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("GoldenImageRegistryMirrors", func() {
+			DescribeTable("should fail if prefix contains transport", func(source, mirror string) {
+				ssp := &sspv1beta3.SSP{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-ssp",
+						Namespace: "test-ns",
+					},
+					Spec: sspv1beta3.SSPSpec{
+						CommonTemplates: sspv1beta3.CommonTemplates{
+							GoldenImageRegistryMirrors: []sspv1beta3.GoldenImageRegistryMirror{{
+								Source: source,
+								Mirror: mirror,
+							}},
+						},
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, ssp)
+				Expect(err).To(MatchError(ContainSubstring("must not contain the transport")))
+			},
+				Entry("in source", "docker://quay.io/containerdisks", "mirror.example.com/containerdisks"),
+				Entry("in mirror", "quay.io/containerdisks", "docker://mirror.example.com/containerdisks"),
+			)
+
+			It("should accept valid mirror", func() {
+				ssp := &sspv1beta3.SSP{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-ssp",
+						Namespace: "test-ns",
+					},
+					Spec: sspv1beta3.SSPSpec{
+						CommonTemplates: sspv1beta3.CommonTemplates{
+							GoldenImageRegistryMirrors: []sspv1beta3.GoldenImageRegistryMirror{{
+								Source: "quay.io/containerdisks",
+								Mirror: "mirror.example.com/containerdisks",
+							}},
+						},
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, ssp)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("GoldenImageAccess", func() {
+			DescribeTable("should validate namespace of viewer", func(kind, namespace string, valid bool) {
+				ssp := &sspv1beta3.SSP{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-ssp",
+						Namespace: "test-ns",
+					},
+					Spec: sspv1beta3.SSPSpec{
+						CommonTemplates: sspv1beta3.CommonTemplates{
+							GoldenImageAccess: &sspv1beta3.GoldenImageAccess{
+								Viewers: []sspv1beta3.GoldenImageSubject{{
+									Kind:      kind,
+									Name:      "tenant",
+									Namespace: namespace,
+								}},
+							},
+						},
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, ssp)
+				if valid {
+					Expect(err).ToNot(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring("goldenImageAccess validation error")))
+				}
+			},
+				Entry("ServiceAccount with namespace", "ServiceAccount", "tenant-ns", true),
+				Entry("ServiceAccount without namespace", "ServiceAccount", "", false),
+				Entry("Group without namespace", "Group", "", true),
+				Entry("Group with namespace", "Group", "tenant-ns", false),
+				Entry("User without namespace", "User", "", true),
+				Entry("User with namespace", "User", "tenant-ns", false),
+			)
+		})
+
 		Context("Cluster", func() {
 			It("should fail if multi-arch is enabled and .spec.cluster is nil", func() {
 				ssp := &sspv1beta3.SSP{